@@ -0,0 +1,270 @@
+package gfuture
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ScanRowAsync creates a Future that runs scan against the row asynchronously
+// and resolves with the scanned value or error.
+// If the context is done first, it resolves with the cancellation error.
+func ScanRowAsync[T any](ctx context.Context, row *sql.Row, scan func(*sql.Row) (T, error)) Future[T] {
+	return Async(func() (T, error) {
+		return scan(row)
+	}).WithContext(ctx)
+}
+
+// RunCmdAsync creates a Future that runs the named command asynchronously and resolves
+// with its combined output or error.
+// The process is killed if the context is done before the command completes.
+func RunCmdAsync(ctx context.Context, name string, args ...string) Future[[]byte] {
+	return Async(func() ([]byte, error) {
+		return exec.CommandContext(ctx, name, args...).CombinedOutput()
+	})
+}
+
+// FromCond creates a Future that waits on the condition variable and resolves once
+// ready reports done, with the value and error it returned.
+// ready is called with the lock of cond held, at start and after every signal.
+func FromCond[T any](cond *sync.Cond, ready func() (T, error, bool)) Future[T] {
+	return spawn(func() (T, error) {
+		cond.L.Lock()
+		defer cond.L.Unlock()
+		for {
+			if value, err, done := ready(); done {
+				return value, err
+			}
+			cond.Wait()
+		}
+	})
+}
+
+// WatchFile creates a Future that polls the path every poll interval and resolves with its
+// FileInfo once the file appears or, if it already exists, once its modification time changes.
+// If the context is done first, it resolves with the cancellation error.
+func WatchFile(ctx context.Context, path string, poll time.Duration) Future[os.FileInfo] {
+	return spawn(func() (os.FileInfo, error) {
+		initial, err := os.Stat(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		timer := currentClock().NewTimer(poll)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C():
+				timer.Reset(poll)
+				info, err := os.Stat(path)
+				switch {
+				case errors.Is(err, fs.ErrNotExist):
+					continue
+				case err != nil:
+					return nil, err
+				case initial == nil || !info.ModTime().Equal(initial.ModTime()):
+					return info, nil
+				}
+			case <-ctx.Done():
+				return nil, cancelled(ctx)
+			}
+		}
+	})
+}
+
+// Weighted is the subset of golang.org/x/sync/semaphore.Weighted used by AsyncWeighted.
+type Weighted interface {
+	Acquire(ctx context.Context, n int64) error
+	Release(n int64)
+}
+
+// AsyncWeighted creates a Future that acquires weight from the semaphore, executes the provider
+// asynchronously and releases the weight once the provider returns.
+// If the weight cannot be acquired before the context is done, it resolves with the acquire error.
+func AsyncWeighted[T any](ctx context.Context, sem Weighted, weight int64, provider func() (T, error)) Future[T] {
+	return spawn(func() (T, error) {
+		if err := sem.Acquire(ctx, weight); err != nil {
+			var zero T
+			return zero, err
+		}
+		defer sem.Release(weight)
+		return provider()
+	})
+}
+
+// Cache is the minimal interface of a key-value cache client, such as Redis, used by GetCacheAsync.
+// Get returns a nil slice and no error when the key is not found.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// GetCacheAsync creates a Future that fetches the key from the cache asynchronously
+// and resolves with the value returned by decode.
+// If the key is not found, it resolves with ErrCacheMiss.
+func GetCacheAsync[T any](ctx context.Context, client Cache, key string, decode func([]byte) (T, error)) Future[T] {
+	return Async(func() (T, error) {
+		data, err := client.Get(ctx, key)
+		if err == nil && data == nil {
+			err = ErrCacheMiss
+		}
+
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return decode(data)
+	})
+}
+
+// FromWaitGroup creates a Future that resolves once wg.Wait returns.
+func FromWaitGroup(wg *sync.WaitGroup) Future[struct{}] {
+	return spawn(func() (struct{}, error) {
+		wg.Wait()
+		return struct{}{}, nil
+	})
+}
+
+// CallAsync creates a Future that invokes the unary call with the given context and request
+// asynchronously, and resolves with its response or error.
+func CallAsync[Req, Resp any](ctx context.Context, call func(context.Context, Req) (Resp, error), req Req) Future[Resp] {
+	return Async(func() (Resp, error) {
+		return call(ctx, req)
+	})
+}
+
+// FromContext creates a Future that resolves with the cancellation cause of the context once it is done,
+// which is the context error unless a cause was set with context.WithCancelCause or similar.
+func FromContext(ctx context.Context) Future[struct{}] {
+	return spawn(func() (struct{}, error) {
+		<-ctx.Done()
+		return struct{}{}, context.Cause(ctx)
+	})
+}
+
+// AwaitAutoClose waits for the Future to resolve and returns the resource it resolves with,
+// handing its ownership to the caller.
+// If the context is done first, the resource the Future later resolves with is closed,
+// so it does not leak.
+func AwaitAutoClose[T io.Closer](ctx context.Context, f Future[T]) (T, error) {
+	value, err, resolved := f.AwaitStatus(ctx)
+	if !resolved {
+		go func() {
+			if value, err := f.Await(context.Background()); err == nil {
+				value.Close()
+			}
+		}()
+	}
+	return value, err
+}
+
+// IntoContext returns a copy of the context carrying the Future under the given key.
+// The Future is only stored, it is not awaited.
+func (f Future[T]) IntoContext(ctx context.Context, key any) context.Context {
+	return context.WithValue(ctx, key, f)
+}
+
+// FromContextValue returns the Future stored in the context under the given key by IntoContext,
+// and whether a Future of that type was found.
+func FromContextValue[T any](ctx context.Context, key any) (Future[T], bool) {
+	f, ok := ctx.Value(key).(Future[T])
+	return f, ok
+}
+
+// AwaitTo waits for the Future to resolve and writes the bytes it resolves with to w,
+// returning the number of bytes written.
+// If the Future fails, nothing is written and its error is returned.
+func AwaitTo(ctx context.Context, f Future[[]byte], w io.Writer) (int, error) {
+	data, err := f.Await(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(data)
+}
+
+// AwaitJSON waits for the Future to resolve and unmarshals the JSON bytes it resolves with into out.
+// If the Future fails, out is left untouched and its error is returned.
+func AwaitJSON(ctx context.Context, f Future[[]byte], out any) error {
+	data, err := f.Await(ctx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// AwaitRetryStatus waits for the Future to resolve and, while the response status is one of retryOn,
+// awaits a new Future from factory, up to attempts in total.
+// It returns the first response with another status, or the last one once the attempts are used up.
+// The bodies of discarded responses are closed, and errors are returned without retrying.
+func AwaitRetryStatus(ctx context.Context, f Future[*http.Response], retryOn []int, factory func() Future[*http.Response], attempts int) (*http.Response, error) {
+	resp, err := f.Await(ctx)
+	for attempt := 1; attempt < attempts && err == nil && slices.Contains(retryOn, resp.StatusCode); attempt++ {
+		resp.Body.Close()
+		if ctx.Err() != nil {
+			return nil, cancelled(ctx)
+		}
+		resp, err = factory().Await(ctx)
+	}
+	return resp, err
+}
+
+// AllToFile waits for all futures and streams the value of each one, in completion order,
+// to the file at path using encode, so the values are never held in memory together.
+// It returns a Future resolved with the number of values written.
+// The first error aborts the writing, and the partial file is left in place for inspection.
+func AllToFile[T any](ctx context.Context, futures []Future[T], path string, encode func(io.Writer, T) error) Future[int] {
+	return spawn(func() (count int, err error) {
+		file, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				return count, result.Err
+			}
+
+			if err := encode(file, result.Value); err != nil {
+				return count, err
+			}
+			count++
+		}
+		return count, nil
+	})
+}
+
+// Tracer is the minimal interface of a tracer, such as an OpenTelemetry one, used by AwaitTraced.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is the minimal interface of a span started by a Tracer.
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+// AwaitTraced waits for the Future to resolve within a span started by tracer with the given name.
+// The error, if any, is recorded on the span, which is ended before returning.
+func (f Future[T]) AwaitTraced(ctx context.Context, tracer Tracer, name string) (T, error) {
+	ctx, span := tracer.StartSpan(ctx, name)
+	defer span.End()
+	value, err := f.Await(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return value, err
+}
@@ -0,0 +1,671 @@
+package gfuture
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScanRowAsync(t *testing.T) {
+	// given
+	ctx := context.Background()
+	row := &sql.Row{}
+	var scanned *sql.Row
+	// when
+	value, err := ScanRowAsync(ctx, row, func(r *sql.Row) (int, error) {
+		scanned = r
+		return 42, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if scanned != row {
+		t.Fatal("Expected scan to receive the row")
+	}
+}
+
+func TestRunCmdAsync(t *testing.T) {
+	// given
+	ctx := context.Background()
+	// when
+	output, err := RunCmdAsync(ctx, "echo", "hello").Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(output) != "hello\n" {
+		t.Fatalf("Expected output hello, got %q", output)
+	}
+}
+
+func TestRunCmdAsyncWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	// when
+	_, err := RunCmdAsync(ctx, "sleep", "5").Await(context.Background())
+	// then
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+
+	if time.Since(start) > time.Second {
+		t.Fatal("Expected the process to be killed")
+	}
+}
+
+func TestFromCond(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cond := sync.NewCond(&sync.Mutex{})
+	counter := 0
+	future := FromCond(cond, func() (int, error, bool) {
+		return counter, nil, counter == 3
+	})
+	// when
+	for i := 0; i < 3; i++ {
+		cond.L.Lock()
+		counter++
+		cond.L.Unlock()
+		cond.Broadcast()
+	}
+	value, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 3 {
+		t.Fatalf("Expected value 3, got %v", value)
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "watched")
+	future := WatchFile(ctx, path, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	// when
+	if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if info.Name() != "watched" {
+		t.Fatalf("Expected file watched, got %v", info.Name())
+	}
+}
+
+func TestWatchFileWithChange(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "watched")
+	if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	modTime := time.Now().Add(time.Hour)
+	future := WatchFile(ctx, path, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	// when
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !info.ModTime().Equal(modTime) {
+		t.Fatalf("Expected modification time %v, got %v", modTime, info.ModTime())
+	}
+}
+
+func TestWatchFileWithTimeout(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "missing")
+	// when
+	_, err := WatchFile(ctx, path, 10*time.Millisecond).Await(context.Background())
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
+
+type testSemaphore struct {
+	mu       sync.Mutex
+	acquired int64
+	size     int64
+}
+
+func (s *testSemaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.acquired+n > s.size {
+		return errors.New("semaphore full")
+	}
+	s.acquired += n
+	return nil
+}
+
+func (s *testSemaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acquired -= n
+}
+
+func TestAsyncWeighted(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sem := &testSemaphore{size: 2}
+	var acquired int64
+	// when
+	value, err := AsyncWeighted(ctx, sem, 2, func() (int, error) {
+		acquired = sem.acquired
+		return 42, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if acquired != 2 || sem.acquired != 0 {
+		t.Fatalf("Expected weight 2 to be acquired and released, got %v and %v", acquired, sem.acquired)
+	}
+}
+
+func TestAsyncWeightedWithAcquireError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	sem := &testSemaphore{size: 1}
+	called := false
+	// when
+	_, err := AsyncWeighted(ctx, sem, 2, func() (int, error) {
+		called = true
+		return 42, nil
+	}).Await(ctx)
+	// then
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+
+	if called {
+		t.Fatal("Expected the provider not to be called")
+	}
+}
+
+type testCache map[string][]byte
+
+func (c testCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c[key], nil
+}
+
+func TestGetCacheAsync(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cache := testCache{"answer": []byte("42")}
+	// when
+	value, err := GetCacheAsync(ctx, cache, "answer", func(data []byte) (int, error) {
+		return strconv.Atoi(string(data))
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestGetCacheAsyncWithMiss(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cache := testCache{}
+	// when
+	_, err := GetCacheAsync(ctx, cache, "answer", func(data []byte) (int, error) {
+		return strconv.Atoi(string(data))
+	}).Await(ctx)
+	// then
+	if err != ErrCacheMiss {
+		t.Fatalf("Expected error %v, got %v", ErrCacheMiss, err)
+	}
+}
+
+func TestFromWaitGroup(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	var done atomic.Int32
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(10 * time.Millisecond)
+			done.Add(1)
+		}()
+	}
+	// when
+	_, err := FromWaitGroup(&wg).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if done.Load() != 3 {
+		t.Fatalf("Expected 3 completed goroutines, got %v", done.Load())
+	}
+}
+
+func TestCallAsync(t *testing.T) {
+	// given
+	ctx := context.WithValue(context.Background(), "key", "value")
+	var received any
+	call := func(ctx context.Context, req int) (string, error) {
+		received = ctx.Value("key")
+		return fmt.Sprint(req * 2), nil
+	}
+	// when
+	resp, err := CallAsync(ctx, call, 21).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp != "42" {
+		t.Fatalf("Expected response 42, got %v", resp)
+	}
+
+	if received != "value" {
+		t.Fatalf("Expected the call to receive the context, got %v", received)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	future := FromContext(ctx)
+	// when
+	cancel()
+	_, err := future.Await(context.Background())
+	// then
+	if err != context.Canceled {
+		t.Fatalf("Expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestFromContextWithCause(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancelCause(context.Background())
+	expectedCause := errors.New("test cause")
+	future := FromContext(ctx)
+	// when
+	cancel(expectedCause)
+	_, err := future.Await(context.Background())
+	// then
+	if err != expectedCause {
+		t.Fatalf("Expected error %v, got %v", expectedCause, err)
+	}
+}
+
+type testCloser struct {
+	closed chan struct{}
+}
+
+func (c *testCloser) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func TestAwaitAutoClose(t *testing.T) {
+	// given
+	ctx := context.Background()
+	resource := &testCloser{closed: make(chan struct{})}
+	// when
+	value, err := AwaitAutoClose(ctx, Async(func() (*testCloser, error) {
+		return resource, nil
+	}))
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != resource {
+		t.Fatal("Expected the resource to be returned")
+	}
+
+	select {
+	case <-resource.closed:
+		t.Fatal("Expected the resource not to be closed")
+	default:
+	}
+}
+
+func TestAwaitAutoCloseWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	resource := &testCloser{closed: make(chan struct{})}
+	// when
+	_, err := AwaitAutoClose(ctx, Async(func() (*testCloser, error) {
+		time.Sleep(100 * time.Millisecond)
+		return resource, nil
+	}))
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	select {
+	case <-resource.closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the resource to be closed")
+	}
+}
+
+type testContextKey struct{}
+
+func TestIntoContext(t *testing.T) {
+	// given
+	future := Async(func() (int, error) {
+		return 42, nil
+	})
+	ctx := future.IntoContext(context.Background(), testContextKey{})
+	// when
+	stored, ok := FromContextValue[int](ctx, testContextKey{})
+	// then
+	if !ok {
+		t.Fatal("Expected a future in the context")
+	}
+
+	value, err := stored.Await(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestFromContextValueWithOtherType(t *testing.T) {
+	// given
+	ctx := NewFuture[int]().IntoContext(context.Background(), testContextKey{})
+	// when
+	_, ok := FromContextValue[string](ctx, testContextKey{})
+	// then
+	if ok {
+		t.Fatal("Expected no future of type string in the context")
+	}
+}
+
+func TestAwaitTo(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var buffer bytes.Buffer
+	// when
+	n, err := AwaitTo(ctx, Async(func() ([]byte, error) {
+		return []byte("hello"), nil
+	}), &buffer)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n != 5 || buffer.String() != "hello" {
+		t.Fatalf("Expected 5 bytes hello, got %v bytes %q", n, buffer.String())
+	}
+}
+
+func TestAwaitToWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	var buffer bytes.Buffer
+	// when
+	n, err := AwaitTo(ctx, Async(func() ([]byte, error) {
+		return nil, expectedErr
+	}), &buffer)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if n != 0 || buffer.Len() != 0 {
+		t.Fatalf("Expected nothing written, got %v bytes", n)
+	}
+}
+
+func TestAwaitJSON(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var out struct {
+		Answer int `json:"answer"`
+	}
+	// when
+	err := AwaitJSON(ctx, Async(func() ([]byte, error) {
+		return []byte(`{"answer": 42}`), nil
+	}), &out)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if out.Answer != 42 {
+		t.Fatalf("Expected answer 42, got %v", out.Answer)
+	}
+}
+
+func TestAwaitJSONWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	var out map[string]int
+	// when
+	err := AwaitJSON(ctx, Async(func() ([]byte, error) {
+		return nil, expectedErr
+	}), &out)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if out != nil {
+		t.Fatalf("Expected nothing unmarshaled, got %v", out)
+	}
+}
+
+func TestAwaitRetryStatus(t *testing.T) {
+	// given
+	ctx := context.Background()
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	calls := 0
+	factory := func() Future[*http.Response] {
+		return Async(func() (*http.Response, error) {
+			status := statuses[calls]
+			calls++
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		})
+	}
+	retryOn := []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+	// when
+	resp, err := AwaitRetryStatus(ctx, factory(), retryOn, factory, 3)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("Expected status 200 after 3 calls, got %v after %v calls", resp.StatusCode, calls)
+	}
+}
+
+func TestAwaitRetryStatusWithExhaustedAttempts(t *testing.T) {
+	// given
+	ctx := context.Background()
+	calls := 0
+	factory := func() Future[*http.Response] {
+		return Async(func() (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+		})
+	}
+	// when
+	resp, err := AwaitRetryStatus(ctx, factory(), []int{http.StatusServiceUnavailable}, factory, 2)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 2 {
+		t.Fatalf("Expected status 503 after 2 calls, got %v after %v calls", resp.StatusCode, calls)
+	}
+}
+
+func TestAllToFile(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 2, nil }),
+	}
+	encode := func(w io.Writer, value int) error {
+		_, err := fmt.Fprintln(w, value)
+		return err
+	}
+	// when
+	count, err := AllToFile(ctx, futures, path, encode).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if count != 2 {
+		t.Fatalf("Expected 2 values written, got %v", count)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if content := string(data); content != "1\n2\n" && content != "2\n1\n" {
+		t.Fatalf("Expected both values in the file, got %q", content)
+	}
+}
+
+func TestAllToFileWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results")
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 0, expectedErr
+		}),
+	}
+	encode := func(w io.Writer, value int) error {
+		_, err := fmt.Fprintln(w, value)
+		return err
+	}
+	// when
+	count, err := AllToFile(ctx, futures, path, encode).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if count != 1 {
+		t.Fatalf("Expected 1 value written, got %v", count)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(data) != "1\n" {
+		t.Fatalf("Expected the partial file to be kept, got %q", data)
+	}
+}
+
+type testSpan struct {
+	name  string
+	err   error
+	ended bool
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (tr *testTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span := &testSpan{name: name}
+	tr.spans = append(tr.spans, span)
+	return ctx, span
+}
+
+func TestAwaitTraced(t *testing.T) {
+	// given
+	ctx := context.Background()
+	tracer := &testTracer{}
+	expectedErr := errors.New("test error")
+	// when
+	_, err := Async(func() (int, error) {
+		return 0, expectedErr
+	}).AwaitTraced(ctx, tracer, "test")
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("Expected 1 span, got %v", len(tracer.spans))
+	}
+
+	span := tracer.spans[0]
+	if span.name != "test" || span.err != expectedErr || !span.ended {
+		t.Fatalf("Expected an ended span test with error %v, got %+v", expectedErr, span)
+	}
+}
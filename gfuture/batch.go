@@ -0,0 +1,665 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type indexed[T any] struct {
+	index    int  // The index of the Future the result belongs to.
+	resolved bool // Whether the Future resolved before the context was done.
+	Result[T]
+}
+
+// settle awaits all futures concurrently and emits each result, tagged with the index
+// of its Future, in completion order.
+// The channel is buffered so that abandoned awaits never block, and it is closed once
+// every Future has resolved or the context is done.
+// Futures still pending when the context is done are drained.
+func settle[T any](ctx context.Context, futures []Future[T]) <-chan indexed[T] {
+	out := make(chan indexed[T], len(futures))
+	var wg sync.WaitGroup
+	for i, f := range futures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err, resolved := f.AwaitStatus(ctx)
+			if !resolved {
+				f.drain()
+			}
+			out <- indexed[T]{index: i, resolved: resolved, Result: Result[T]{Value: value, Err: err}}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Scatter runs all providers concurrently with a shared child context and returns a Future
+// resolved with the first k successful values, in completion order.
+// Once k values are available, the child context is cancelled so the remaining providers can stop.
+// If fewer than k providers can succeed, it resolves with an error joining the provider errors.
+// A negative k is treated as zero.
+func Scatter[T any](ctx context.Context, k int, providers []func(context.Context) (T, error)) Future[[]T] {
+	k = max(k, 0)
+	return spawn(func() ([]T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		futures := make([]Future[T], len(providers))
+		for i, provider := range providers {
+			futures[i] = Async(func() (T, error) {
+				return provider(ctx)
+			})
+		}
+
+		values := make([]T, 0, k)
+		var errs []error
+		pending := len(providers)
+		for result := range settle(ctx, futures) {
+			if len(values) >= k {
+				break
+			}
+
+			pending--
+			if result.Err != nil {
+				errs = append(errs, result.Err)
+			} else {
+				values = append(values, result.Value)
+			}
+
+			if len(values) >= k || len(values)+pending < k {
+				break
+			}
+		}
+
+		if len(values) < k {
+			return nil, fmt.Errorf("gfuture: %d of %d required providers succeeded: %w", len(values), k, errors.Join(errs...))
+		}
+		return values, nil
+	})
+}
+
+// AnyKeyed waits for the first Future in the map to resolve successfully and returns its key and value.
+// The remaining futures are abandoned.
+// If every Future fails, it returns the errors joined, or the cancellation error if the context is done first.
+func AnyKeyed[K comparable, T any](ctx context.Context, futures map[K]Future[T]) (K, T, error) {
+	keys := make([]K, 0, len(futures))
+	list := make([]Future[T], 0, len(futures))
+	for key, f := range futures {
+		keys = append(keys, key)
+		list = append(list, f)
+	}
+
+	var errs []error
+	for result := range settle(ctx, list) {
+		if result.Err == nil {
+			return keys[result.index], result.Value, nil
+		}
+		errs = append(errs, result.Err)
+	}
+
+	var key K
+	var zero T
+	if ctx.Err() != nil {
+		return key, zero, cancelled(ctx)
+	}
+	if len(errs) == 0 {
+		return key, zero, ErrNoFuture
+	}
+	return key, zero, errors.Join(errs...)
+}
+
+// AllOrPartial waits for all futures and returns the successful values in input order,
+// along with the sorted indices of the futures that did not succeed.
+// The returned error joins the errors of the failed futures and, if the context was done
+// before every Future resolved, the cancellation error.
+// On full success the indices are empty and the error is nil.
+func AllOrPartial[T any](ctx context.Context, futures []Future[T]) ([]T, []int, error) {
+	results := make([]indexed[T], len(futures))
+	for result := range settle(ctx, futures) {
+		results[result.index] = result
+	}
+
+	values := make([]T, 0, len(futures))
+	missing := []int{}
+	var errs []error
+	timedOut := false
+	for i, result := range results {
+		switch {
+		case result.Err == nil:
+			values = append(values, result.Value)
+			continue
+		case !result.resolved:
+			timedOut = true
+		default:
+			errs = append(errs, result.Err)
+		}
+		missing = append(missing, i)
+	}
+
+	if timedOut {
+		errs = append(errs, cancelled(ctx))
+	}
+	return values, missing, errors.Join(errs...)
+}
+
+// BatchTiming holds the values of a batch of futures along with how long each took to resolve.
+type BatchTiming[T any] struct {
+	Values    []T             // The values of the futures, in input order.
+	Durations []time.Duration // The time each Future took to resolve, zero if it did not complete.
+	Total     time.Duration   // The wall-clock time taken by the whole batch.
+}
+
+// AllTimed returns a Future resolved with the values of all futures and their timings,
+// measured from the moment AllTimed is called.
+// On the first error it resolves with that error and the timings of the futures completed so far.
+func AllTimed[T any](ctx context.Context, futures []Future[T]) Future[BatchTiming[T]] {
+	start := currentClock().Now()
+	return spawn(func() (BatchTiming[T], error) {
+		timing := BatchTiming[T]{
+			Values:    make([]T, len(futures)),
+			Durations: make([]time.Duration, len(futures)),
+		}
+		for result := range settle(ctx, futures) {
+			elapsed := since(start)
+			timing.Total = elapsed
+			if result.Err != nil {
+				return timing, result.Err
+			}
+
+			timing.Values[result.index] = result.Value
+			timing.Durations[result.index] = elapsed
+		}
+		timing.Total = since(start)
+		return timing, nil
+	})
+}
+
+// Accumulate returns a Future that calls fn with the accumulator and the value of each
+// Future as it resolves, in completion order.
+// Calls to fn are serialized, so fn never runs concurrently.
+// It resolves with the accumulator once every Future has been processed, along with
+// the errors of the failed futures joined.
+func Accumulate[T, A any](ctx context.Context, futures []Future[T], acc *A, fn func(*A, T)) Future[*A] {
+	return spawn(func() (*A, error) {
+		var errs []error
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				errs = append(errs, result.Err)
+				continue
+			}
+			fn(acc, result.Value)
+		}
+		return acc, errors.Join(errs...)
+	})
+}
+
+// AllWithPerFutureTimeout returns a Future resolved with the result of every Future, in input order,
+// where each Future is awaited with its own timeout d on top of the given context.
+// Futures that take longer than d get a deadline exceeded error in their Result and are drained.
+func AllWithPerFutureTimeout[T any](ctx context.Context, d time.Duration, futures []Future[T]) Future[[]Result[T]] {
+	return spawn(func() ([]Result[T], error) {
+		results := make([]Result[T], len(futures))
+		var wg sync.WaitGroup
+		for i, f := range futures {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(ctx, d)
+				defer cancel()
+				value, err, resolved := f.AwaitStatus(ctx)
+				if !resolved {
+					f.drain()
+				}
+				results[i] = Result[T]{Value: value, Err: err}
+			}()
+		}
+		wg.Wait()
+		return results, nil
+	})
+}
+
+// Spread calls fn for each item and returns the resulting futures, in input order.
+func Spread[T, U any](items []T, fn func(T) Future[U]) []Future[U] {
+	futures := make([]Future[U], len(items))
+	for i, item := range items {
+		futures[i] = fn(item)
+	}
+	return futures
+}
+
+// FirstN returns a Future resolved with the values of the first n futures by input index,
+// in input order, once all of them have succeeded.
+// If any of them fails, it resolves with that error.
+// Futures beyond index n-1 are drained and their results discarded; if there are fewer
+// than n futures, all of them are used.
+func FirstN[T any](ctx context.Context, n int, futures []Future[T]) Future[[]T] {
+	n = min(max(n, 0), len(futures))
+	drainAll(futures[n:])
+
+	leading := futures[:n]
+	return spawn(func() ([]T, error) {
+		values := make([]T, n)
+		for result := range settle(ctx, leading) {
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			values[result.index] = result.Value
+		}
+		return values, nil
+	})
+}
+
+// AdaptiveOptions holds the tuning parameters of MapSliceAdaptive.
+// Zero values are replaced by their defaults.
+type AdaptiveOptions struct {
+	Initial int           // The initial number of concurrent calls, 1 by default.
+	Max     int           // The maximum number of concurrent calls, the number of items by default.
+	Step    int           // How much the concurrency grows or shrinks at a time, 1 by default.
+	Latency time.Duration // The highest acceptable latency of a call, unlimited by default.
+}
+
+func (o AdaptiveOptions) withDefaults(items int) AdaptiveOptions {
+	if o.Max <= 0 {
+		o.Max = max(items, 1)
+	}
+	if o.Initial <= 0 {
+		o.Initial = 1
+	}
+	if o.Step <= 0 {
+		o.Step = 1
+	}
+	o.Initial = min(o.Initial, o.Max)
+	return o
+}
+
+// MapSliceAdaptive returns a Future resolved with the result of fn applied to every item, in input order.
+// It starts with a low concurrency and increases it by Step while calls complete within Latency,
+// decreasing it when they are slower and halving it when they fail.
+// Failed items leave a zero value in the result and their errors are joined.
+func MapSliceAdaptive[T, U any](ctx context.Context, items []T, fn func(T) (U, error), opts AdaptiveOptions) Future[[]U] {
+	opts = opts.withDefaults(len(items))
+	type outcome struct {
+		index   int
+		value   U
+		err     error
+		latency time.Duration
+	}
+
+	return spawn(func() ([]U, error) {
+		values := make([]U, len(items))
+		var errs []error
+		outcomes := make(chan outcome, len(items))
+		limit, running, next := opts.Initial, 0, 0
+		for next < len(items) || running > 0 {
+			for running < limit && next < len(items) {
+				i := next
+				next++
+				running++
+				go func() {
+					start := currentClock().Now()
+					value, err := fn(items[i])
+					outcomes <- outcome{index: i, value: value, err: err, latency: since(start)}
+				}()
+			}
+
+			select {
+			case o := <-outcomes:
+				running--
+				switch {
+				case o.err != nil:
+					errs = append(errs, o.err)
+					limit = max(limit/2, 1)
+				case opts.Latency > 0 && o.latency > opts.Latency:
+					values[o.index] = o.value
+					limit = max(limit-opts.Step, 1)
+				default:
+					values[o.index] = o.value
+					limit = min(limit+opts.Step, opts.Max)
+				}
+			case <-ctx.Done():
+				return nil, cancelled(ctx)
+			}
+		}
+		return values, errors.Join(errs...)
+	})
+}
+
+// AllCancel runs all providers concurrently with a shared child context and returns a Future
+// resolved with their values, in input order.
+// As soon as a provider fails, the child context is cancelled so the other providers can stop,
+// and the Future resolves with that error.
+func AllCancel[T any](ctx context.Context, providers []func(context.Context) (T, error)) Future[[]T] {
+	return spawn(func() ([]T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		futures := make([]Future[T], len(providers))
+		for i, provider := range providers {
+			futures[i] = Async(func() (T, error) {
+				return provider(ctx)
+			})
+		}
+
+		values := make([]T, len(providers))
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			values[result.index] = result.Value
+		}
+		return values, nil
+	})
+}
+
+// MergeMaps returns a Future resolved with the maps of all futures merged into one.
+// Keys are merged by input index, so a later Future overrides the keys of an earlier one.
+// The first error short-circuits the merge.
+func MergeMaps[K comparable, V any](ctx context.Context, futures []Future[map[K]V]) Future[map[K]V] {
+	return spawn(func() (map[K]V, error) {
+		maps := make([]map[K]V, len(futures))
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			maps[result.index] = result.Value
+		}
+
+		merged := make(map[K]V)
+		for _, m := range maps {
+			for key, value := range m {
+				merged[key] = value
+			}
+		}
+		return merged, nil
+	})
+}
+
+// Summary holds the outcome counts and latency statistics of a batch of futures.
+type Summary struct {
+	Successes   int           // The number of futures that succeeded.
+	Failures    int           // The number of futures that failed.
+	MinLatency  time.Duration // The shortest time taken by a Future to resolve.
+	MaxLatency  time.Duration // The longest time taken by a Future to resolve.
+	MeanLatency time.Duration // The mean time taken by the futures to resolve.
+}
+
+// Summarize returns a Future resolved with a Summary of all futures once every one of them
+// has resolved, with latencies measured from the moment Summarize is called.
+// Futures still pending when the context is done count as failures.
+func Summarize[T any](ctx context.Context, futures []Future[T]) Future[Summary] {
+	start := currentClock().Now()
+	return spawn(func() (Summary, error) {
+		var summary Summary
+		var total time.Duration
+		for result := range settle(ctx, futures) {
+			latency := since(start)
+			if result.Err != nil {
+				summary.Failures++
+			} else {
+				summary.Successes++
+			}
+
+			if summary.Successes+summary.Failures == 1 || latency < summary.MinLatency {
+				summary.MinLatency = latency
+			}
+			summary.MaxLatency = max(summary.MaxLatency, latency)
+			total += latency
+		}
+
+		if count := summary.Successes + summary.Failures; count > 0 {
+			summary.MeanLatency = total / time.Duration(count)
+		}
+		return summary, nil
+	})
+}
+
+// FirstSuccessByPriority returns a Future resolved with the value of the successful Future
+// with the lowest index, which has the highest priority.
+// A Future is only chosen once every Future before it has failed, regardless of which
+// completes first, and if all of them fail it resolves with their errors joined.
+func FirstSuccessByPriority[T any](ctx context.Context, futures []Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		results := make([]*Result[T], len(futures))
+		errs := make([]error, 0, len(futures))
+		next := 0
+		for result := range settle(ctx, futures) {
+			results[result.index] = &result.Result
+			for next < len(results) && results[next] != nil {
+				if results[next].Err == nil {
+					return results[next].Value, nil
+				}
+				errs = append(errs, results[next].Err)
+				next++
+			}
+		}
+
+		var zero T
+		if len(errs) == 0 {
+			return zero, ErrNoFuture
+		}
+		return zero, errors.Join(errs...)
+	})
+}
+
+// Consensus returns a Future resolved with the first value that quorum futures succeed with.
+// The remaining futures are abandoned once a value reaches the quorum.
+// If no value reaches it after every Future has resolved, it resolves with an error wrapping
+// ErrNoQuorum that describes the votes of each value.
+func Consensus[T comparable](ctx context.Context, quorum int, futures []Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		votes := make(map[T]int)
+		failures := 0
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				failures++
+				continue
+			}
+
+			votes[result.Value]++
+			if votes[result.Value] >= quorum {
+				return result.Value, nil
+			}
+		}
+
+		var zero T
+		return zero, fmt.Errorf("%w: %d votes required, got %v with %d failures", ErrNoQuorum, quorum, votes, failures)
+	})
+}
+
+// ReduceWhile returns a Future that folds the values of the futures, in input order, into
+// an accumulator using fn, for as long as fn reports that it should continue.
+// Once fn returns false, it resolves with the current accumulator and the remaining futures
+// are drained. The first error short-circuits the fold.
+func ReduceWhile[T, A any](ctx context.Context, futures []Future[T], initial A, fn func(A, T) (A, bool)) Future[A] {
+	return spawn(func() (A, error) {
+		acc := initial
+		for i, f := range futures {
+			value, err, resolved := f.AwaitStatus(ctx)
+			if err != nil {
+				if !resolved {
+					f.drain()
+				}
+				drainAll(futures[i+1:])
+				var zero A
+				return zero, err
+			}
+
+			var proceed bool
+			if acc, proceed = fn(acc, value); !proceed {
+				drainAll(futures[i+1:])
+				break
+			}
+		}
+		return acc, nil
+	})
+}
+
+// FirstWhere returns a Future resolved with the first value, in completion order, of a
+// successful Future that satisfies pred. The remaining futures are abandoned.
+// If no Future qualifies, it resolves with an error wrapping ErrNoMatch and the errors
+// of the failed futures.
+func FirstWhere[T any](ctx context.Context, pred func(T) bool, futures []Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		var errs []error
+		for result := range settle(ctx, futures) {
+			switch {
+			case result.Err != nil:
+				errs = append(errs, result.Err)
+			case pred(result.Value):
+				return result.Value, nil
+			}
+		}
+		var zero T
+		return zero, noMatch(errs)
+	})
+}
+
+// FirstNonNil returns a Future resolved with the first non-nil pointer any of the futures
+// succeeds with, in completion order. The remaining futures are abandoned.
+// If every Future fails or resolves with nil, it resolves with an error wrapping ErrNoMatch
+// and the errors of the failed futures.
+func FirstNonNil[T any](ctx context.Context, futures []Future[*T]) Future[*T] {
+	return FirstWhere(ctx, func(value *T) bool {
+		return value != nil
+	}, futures)
+}
+
+func noMatch(errs []error) error {
+	if len(errs) == 0 {
+		return ErrNoMatch
+	}
+	return fmt.Errorf("%w: %w", ErrNoMatch, errors.Join(errs...))
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns a Future resolved with the sum of the values of all futures, or with the first error.
+// Overflow wraps around as with the + operator.
+func Sum[T Number](ctx context.Context, futures []Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		var sum T
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				return 0, result.Err
+			}
+			sum += result.Value
+		}
+		return sum, nil
+	})
+}
+
+// MedianLatency waits for all futures to settle and returns a Future resolved with the value
+// of the successful Future with the median latency, measured from the call.
+// With an even number of successes, the faster of the two middle futures is used.
+// Failed futures are excluded, and if none succeeds it resolves with their joined errors,
+// or with ErrNoFuture if there are no futures.
+func MedianLatency[T any](ctx context.Context, futures []Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		var values []T
+		var errs []error
+		for result := range settle(ctx, futures) {
+			if result.Err != nil {
+				errs = append(errs, result.Err)
+				continue
+			}
+			values = append(values, result.Value)
+		}
+
+		var zero T
+		switch {
+		case ctx.Err() != nil:
+			return zero, cancelled(ctx)
+		case len(values) > 0:
+			return values[(len(values)-1)/2], nil
+		case len(errs) > 0:
+			return zero, errors.Join(errs...)
+		default:
+			return zero, ErrNoFuture
+		}
+	})
+}
+
+// ZipWith waits for the futures of both slices and returns a Future resolved with fn applied
+// to every pair of values at the same index, or with the first error.
+// When the slices have different lengths, the result is truncated to the shorter one and
+// the extra futures of the longer slice are drained.
+func ZipWith[A, B, C any](ctx context.Context, as []Future[A], bs []Future[B], fn func(A, B) C) Future[[]C] {
+	n := min(len(as), len(bs))
+	drainAll(as[n:])
+	drainAll(bs[n:])
+
+	as, bs = as[:n], bs[:n]
+	return spawn(func() ([]C, error) {
+		left, right := make([]A, n), make([]B, n)
+		leftCh, rightCh := settle(ctx, as), settle(ctx, bs)
+		for leftCh != nil || rightCh != nil {
+			select {
+			case result, ok := <-leftCh:
+				if !ok {
+					leftCh = nil
+					continue
+				}
+
+				if result.Err != nil {
+					return nil, result.Err
+				}
+				left[result.index] = result.Value
+			case result, ok := <-rightCh:
+				if !ok {
+					rightCh = nil
+					continue
+				}
+
+				if result.Err != nil {
+					return nil, result.Err
+				}
+				right[result.index] = result.Value
+			}
+		}
+
+		values := make([]C, n)
+		for i := range values {
+			values[i] = fn(left[i], right[i])
+		}
+		return values, nil
+	})
+}
+
+// Tiered runs the providers of each tier concurrently and returns a Future resolved with the
+// first success of a tier, moving on to the next tier only once every provider of the current
+// one has failed. The remaining providers of the successful tier are abandoned.
+// If every tier fails it resolves with all the errors joined, or with ErrNoFuture if there
+// are no providers.
+func Tiered[T any](ctx context.Context, tiers [][]func() (T, error)) Future[T] {
+	return spawn(func() (T, error) {
+		var zero T
+		var errs []error
+		for _, tier := range tiers {
+			for result := range settle(ctx, Spread(tier, Async)) {
+				if result.Err == nil {
+					return result.Value, nil
+				}
+				errs = append(errs, result.Err)
+			}
+
+			if ctx.Err() != nil {
+				return zero, cancelled(ctx)
+			}
+		}
+
+		if len(errs) == 0 {
+			return zero, ErrNoFuture
+		}
+		return zero, errors.Join(errs...)
+	})
+}
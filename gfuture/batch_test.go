@@ -0,0 +1,1036 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScatter(t *testing.T) {
+	// given
+	ctx := context.Background()
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		},
+		func(ctx context.Context) (int, error) {
+			return 2, nil
+		},
+		func(ctx context.Context) (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 3, nil
+		},
+	}
+	// when
+	values, err := Scatter(ctx, 2, providers).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 2 || values[0] != 2 || values[1] != 3 {
+		t.Fatalf("Expected values [2 3], got %v", values)
+	}
+}
+
+func TestScatterWithNotEnoughSuccesses(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
+		func(ctx context.Context) (int, error) {
+			return 0, expectedErr
+		},
+	}
+	// when
+	values, err := Scatter(ctx, 2, providers).Await(ctx)
+	// then
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if values != nil {
+		t.Fatalf("Expected nil values, got %v", values)
+	}
+}
+
+func TestScatterWithNegativeCount(t *testing.T) {
+	// given
+	ctx := context.Background()
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
+	}
+	// when
+	values, err := Scatter(ctx, -1, providers).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 0 {
+		t.Fatalf("Expected no values, got %v", values)
+	}
+}
+
+func TestScatterCancelsRemainingProviders(t *testing.T) {
+	// given
+	ctx := context.Background()
+	stopped := make(chan error, 1)
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			stopped <- ctx.Err()
+			return 0, ctx.Err()
+		},
+		func(ctx context.Context) (int, error) {
+			return 2, nil
+		},
+	}
+	// when
+	values, err := Scatter(ctx, 1, providers).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 1 || values[0] != 2 {
+		t.Fatalf("Expected values [2], got %v", values)
+	}
+
+	select {
+	case err := <-stopped:
+		if err != context.Canceled {
+			t.Fatalf("Expected error %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the remaining provider to observe the cancellation")
+	}
+}
+
+func TestAnyKeyed(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := map[string]Future[int]{
+		"cache": Async(func() (int, error) {
+			return 0, errors.New("test error")
+		}),
+		"db": Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 42, nil
+		}),
+		"replica": Async(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		}),
+	}
+	// when
+	key, value, err := AnyKeyed(ctx, futures)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if key != "db" {
+		t.Fatalf("Expected key db, got %v", key)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestAnyKeyedWithTimeout(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	futures := map[string]Future[int]{
+		"db": Async(func() (int, error) {
+			time.Sleep(time.Second)
+			return 42, nil
+		}),
+	}
+	// when
+	_, _, err := AnyKeyed(ctx, futures)
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
+
+func TestAllOrPartial(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 2, nil }),
+	}
+	// when
+	values, missing, err := AllOrPartial(ctx, futures)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("Expected values [1 2], got %v", values)
+	}
+
+	if len(missing) != 0 {
+		t.Fatalf("Expected no missing indices, got %v", missing)
+	}
+}
+
+func TestAllOrPartialWithTimeout(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) {
+			time.Sleep(time.Second)
+			return 2, nil
+		}),
+		Async(func() (int, error) { return 3, nil }),
+	}
+	// when
+	values, missing, err := AllOrPartial(ctx, futures)
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 3 {
+		t.Fatalf("Expected values [1 3], got %v", values)
+	}
+
+	if len(missing) != 1 || missing[0] != 1 {
+		t.Fatalf("Expected missing indices [1], got %v", missing)
+	}
+}
+
+func TestAllOrPartialWithCancelledProviderError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := fmt.Errorf("upstream: %w", ErrAwaitCancelled)
+	futures := []Future[int]{
+		Async(func() (int, error) { return 0, expectedErr }),
+	}
+	// when
+	_, missing, err := AllOrPartial(ctx, futures)
+	// then
+	if !errors.Is(err, expectedErr) || err.Error() != expectedErr.Error() {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if len(missing) != 1 || missing[0] != 0 {
+		t.Fatalf("Expected missing indices [0], got %v", missing)
+	}
+}
+
+func TestAllTimed(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		}),
+		Async(func() (int, error) {
+			return 2, nil
+		}),
+	}
+	// when
+	timing, err := AllTimed(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(timing.Values) != 2 || timing.Values[0] != 1 || timing.Values[1] != 2 {
+		t.Fatalf("Expected values [1 2], got %v", timing.Values)
+	}
+
+	if timing.Durations[0] < 100*time.Millisecond || timing.Durations[1] >= timing.Durations[0] {
+		t.Fatalf("Unexpected durations %v", timing.Durations)
+	}
+
+	if timing.Total < timing.Durations[0] {
+		t.Fatalf("Expected total of at least %v, got %v", timing.Durations[0], timing.Total)
+	}
+}
+
+func TestAllTimedWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			return 1, nil
+		}),
+		Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 0, expectedErr
+		}),
+	}
+	// when
+	timing, err := AllTimed(ctx, futures).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if timing.Durations[0] == 0 {
+		t.Fatal("Expected a duration for the completed future")
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 2, nil }),
+		Async(func() (int, error) { return 3, nil }),
+	}
+	sum := 0
+	// when
+	acc, err := Accumulate(ctx, futures, &sum, func(acc *int, v int) {
+		*acc += v
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *acc != 6 {
+		t.Fatalf("Expected sum 6, got %v", *acc)
+	}
+}
+
+func TestAccumulateWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 0, expectedErr }),
+	}
+	sum := 0
+	// when
+	_, err := Accumulate(ctx, futures, &sum, func(acc *int, v int) {
+		*acc += v
+	}).Await(ctx)
+	// then
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if sum != 1 {
+		t.Fatalf("Expected sum 1, got %v", sum)
+	}
+}
+
+func TestAllWithPerFutureTimeout(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) {
+			time.Sleep(time.Second)
+			return 2, nil
+		}),
+	}
+	// when
+	results, err := AllWithPerFutureTimeout(ctx, 100*time.Millisecond, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if results[0].Err != nil || results[0].Value != 1 {
+		t.Fatalf("Expected value 1, got %v", results[0])
+	}
+
+	if !errors.Is(results[1].Err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", results[1].Err)
+	}
+}
+
+func TestAllWithPerFutureTimeoutDrainsSlowFutures(t *testing.T) {
+	// given
+	ctx := context.Background()
+	slow := NewFuture[int]()
+	delivered := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		slow.Value(2)
+		close(delivered)
+	}()
+	// when
+	_, err := AllWithPerFutureTimeout(ctx, 10*time.Millisecond, []Future[int]{slow}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the slow future to be drained")
+	}
+}
+
+func TestSpread(t *testing.T) {
+	// given
+	ctx := context.Background()
+	items := []int{1, 2, 3}
+	// when
+	futures := Spread(items, func(item int) Future[int] {
+		return Async(func() (int, error) {
+			return item * 2, nil
+		})
+	})
+	// then
+	if len(futures) != 3 {
+		t.Fatalf("Expected 3 futures, got %v", len(futures))
+	}
+
+	for i, future := range futures {
+		value, err := future.Await(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if value != items[i]*2 {
+			t.Fatalf("Expected value %v, got %v", items[i]*2, value)
+		}
+	}
+}
+
+func TestFirstN(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 1, nil
+		}),
+		Async(func() (int, error) { return 2, nil }),
+		Async(func() (int, error) { return 0, errors.New("test error") }),
+	}
+	// when
+	values, err := FirstN(ctx, 2, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("Expected values [1 2], got %v", values)
+	}
+}
+
+func TestFirstNWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 0, expectedErr }),
+	}
+	// when
+	_, err := FirstN(ctx, 2, futures).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestMapSliceAdaptive(t *testing.T) {
+	// given
+	ctx := context.Background()
+	items := []int{1, 2, 3, 4, 5}
+	var running, peak atomic.Int32
+	// when
+	values, err := MapSliceAdaptive(ctx, items, func(item int) (int, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return item * 2, nil
+	}, AdaptiveOptions{Initial: 1, Max: 2}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i, value := range values {
+		if value != items[i]*2 {
+			t.Fatalf("Expected value %v at index %v, got %v", items[i]*2, i, value)
+		}
+	}
+
+	if peak.Load() > 2 {
+		t.Fatalf("Expected at most 2 concurrent calls, got %v", peak.Load())
+	}
+}
+
+func TestMapSliceAdaptiveWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	items := []int{1, 2, 3}
+	// when
+	values, err := MapSliceAdaptive(ctx, items, func(item int) (int, error) {
+		if item == 2 {
+			return 0, expectedErr
+		}
+		return item, nil
+	}, AdaptiveOptions{}).Await(ctx)
+	// then
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if values[0] != 1 || values[1] != 0 || values[2] != 3 {
+		t.Fatalf("Expected values [1 0 3], got %v", values)
+	}
+}
+
+func TestAllCancel(t *testing.T) {
+	// given
+	ctx := context.Background()
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) { return 1, nil },
+		func(ctx context.Context) (int, error) { return 2, nil },
+	}
+	// when
+	values, err := AllCancel(ctx, providers).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("Expected values [1 2], got %v", values)
+	}
+}
+
+func TestAllCancelWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	stopped := make(chan error, 1)
+	providers := []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			stopped <- ctx.Err()
+			return 0, ctx.Err()
+		},
+		func(ctx context.Context) (int, error) {
+			return 0, expectedErr
+		},
+	}
+	// when
+	_, err := AllCancel(ctx, providers).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled error, got %v", err)
+	}
+}
+
+func TestMergeMaps(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[map[string]int]{
+		Async(func() (map[string]int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return map[string]int{"a": 1, "b": 1}, nil
+		}),
+		Async(func() (map[string]int, error) {
+			return map[string]int{"b": 2, "c": 2}, nil
+		}),
+	}
+	// when
+	merged, err := MergeMaps(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(merged) != 3 || merged["a"] != 1 || merged["b"] != 2 || merged["c"] != 2 {
+		t.Fatalf("Expected map[a:1 b:2 c:2], got %v", merged)
+	}
+}
+
+func TestMergeMapsWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[map[string]int]{
+		Async(func() (map[string]int, error) { return map[string]int{"a": 1}, nil }),
+		Async(func() (map[string]int, error) { return nil, expectedErr }),
+	}
+	// when
+	_, err := MergeMaps(ctx, futures).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 2, nil
+		}),
+		Async(func() (int, error) { return 0, errors.New("test error") }),
+	}
+	// when
+	summary, err := Summarize(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if summary.Successes != 2 || summary.Failures != 1 {
+		t.Fatalf("Expected 2 successes and 1 failure, got %v and %v", summary.Successes, summary.Failures)
+	}
+
+	if summary.MaxLatency < 100*time.Millisecond {
+		t.Fatalf("Expected max latency of at least 100ms, got %v", summary.MaxLatency)
+	}
+
+	if summary.MinLatency > summary.MeanLatency || summary.MeanLatency > summary.MaxLatency {
+		t.Fatalf("Unexpected latencies %v", summary)
+	}
+}
+
+func TestFirstSuccessByPriority(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 0, errors.New("test error")
+		}),
+		Async(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 2, nil
+		}),
+		Async(func() (int, error) {
+			return 3, nil
+		}),
+	}
+	// when
+	value, err := FirstSuccessByPriority(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 2 {
+		t.Fatalf("Expected value 2, got %v", value)
+	}
+}
+
+func TestFirstSuccessByPriorityWithAllFailing(t *testing.T) {
+	// given
+	ctx := context.Background()
+	firstErr := errors.New("first error")
+	secondErr := errors.New("second error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 0, firstErr }),
+		Async(func() (int, error) { return 0, secondErr }),
+	}
+	// when
+	_, err := FirstSuccessByPriority(ctx, futures).Await(ctx)
+	// then
+	if !errors.Is(err, firstErr) || !errors.Is(err, secondErr) {
+		t.Fatalf("Expected errors %v and %v, got %v", firstErr, secondErr, err)
+	}
+}
+
+func TestConsensus(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[string]{
+		Async(func() (string, error) { return "a", nil }),
+		Async(func() (string, error) { return "b", nil }),
+		Async(func() (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "b", nil
+		}),
+	}
+	// when
+	value, err := Consensus(ctx, 2, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != "b" {
+		t.Fatalf("Expected value b, got %v", value)
+	}
+}
+
+func TestConsensusWithoutQuorum(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[string]{
+		Async(func() (string, error) { return "a", nil }),
+		Async(func() (string, error) { return "b", nil }),
+		Async(func() (string, error) { return "", errors.New("test error") }),
+	}
+	// when
+	_, err := Consensus(ctx, 2, futures).Await(ctx)
+	// then
+	if !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("Expected error %v, got %v", ErrNoQuorum, err)
+	}
+}
+
+func TestReduceWhile(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 5, nil }),
+		Async(func() (int, error) { return 7, nil }),
+		Async(func() (int, error) { return 9, nil }),
+		Async(func() (int, error) { return 0, errors.New("test error") }),
+	}
+	// when
+	sum, err := ReduceWhile(ctx, futures, 0, func(acc, v int) (int, bool) {
+		acc += v
+		return acc, acc < 10
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if sum != 12 {
+		t.Fatalf("Expected sum 12, got %v", sum)
+	}
+}
+
+func TestReduceWhileWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 0, expectedErr }),
+		Async(func() (int, error) { return 2, nil }),
+	}
+	// when
+	_, err := ReduceWhile(ctx, futures, 0, func(acc, v int) (int, bool) {
+		return acc + v, true
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestReduceWhileWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	slow := NewFuture[int]()
+	delivered := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		slow.Value(2)
+		close(delivered)
+	}()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		slow,
+	}
+	// when
+	_, err := ReduceWhile(ctx, futures, 0, func(acc, v int) (int, bool) {
+		return acc + v, true
+	}).Await(context.Background())
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the pending future to be drained")
+	}
+}
+
+func TestFirstWhere(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 0, errors.New("test error") }),
+		Async(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 42, nil
+		}),
+	}
+	// when
+	value, err := FirstWhere(ctx, func(value int) bool {
+		return value > 10
+	}, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestFirstWhereWithoutHit(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+	}
+	// when
+	_, err := FirstWhere(ctx, func(value int) bool {
+		return value > 10
+	}, futures).Await(ctx)
+	// then
+	if err != ErrNoMatch {
+		t.Fatalf("Expected error %v, got %v", ErrNoMatch, err)
+	}
+}
+
+func TestFirstNonNil(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expected := 42
+	futures := []Future[*int]{
+		Async(func() (*int, error) { return nil, nil }),
+		Async(func() (*int, error) { return nil, errors.New("test error") }),
+		Async(func() (*int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return &expected, nil
+		}),
+	}
+	// when
+	value, err := FirstNonNil(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != &expected {
+		t.Fatalf("Expected pointer to %v, got %v", expected, value)
+	}
+}
+
+func TestFirstNonNilWithoutHit(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[*int]{
+		Async(func() (*int, error) { return nil, nil }),
+		Async(func() (*int, error) { return nil, expectedErr }),
+	}
+	// when
+	_, err := FirstNonNil(ctx, futures).Await(ctx)
+	// then
+	if !errors.Is(err, ErrNoMatch) || !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected errors %v and %v, got %v", ErrNoMatch, expectedErr, err)
+	}
+}
+
+func TestSum(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[float64]{
+		Async(func() (float64, error) { return 1.5, nil }),
+		Async(func() (float64, error) { return 2.5, nil }),
+	}
+	// when
+	sum, err := Sum(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if sum != 4 {
+		t.Fatalf("Expected sum 4, got %v", sum)
+	}
+}
+
+func TestSumWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 0, expectedErr }),
+	}
+	// when
+	_, err := Sum(ctx, futures).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestMedianLatency(t *testing.T) {
+	// given
+	ctx := context.Background()
+	delayed := func(value int, delay time.Duration) Future[int] {
+		return Async(func() (int, error) {
+			time.Sleep(delay)
+			return value, nil
+		})
+	}
+	futures := []Future[int]{
+		delayed(1, 90*time.Millisecond),
+		Async(func() (int, error) { return 0, errors.New("test error") }),
+		delayed(2, 10*time.Millisecond),
+		delayed(3, 50*time.Millisecond),
+	}
+	// when
+	value, err := MedianLatency(ctx, futures).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 3 {
+		t.Fatalf("Expected value 3, got %v", value)
+	}
+}
+
+func TestMedianLatencyWithAllErrors(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{
+		Async(func() (int, error) { return 0, expectedErr }),
+	}
+	// when
+	_, err := MedianLatency(ctx, futures).Await(ctx)
+	// then
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	// given
+	ctx := context.Background()
+	as := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 2, nil }),
+		Async(func() (int, error) { return 3, nil }),
+	}
+	bs := []Future[string]{
+		Async(func() (string, error) { return "a", nil }),
+		Async(func() (string, error) { return "b", nil }),
+	}
+	// when
+	values, err := ZipWith(ctx, as, bs, func(a int, b string) string {
+		return fmt.Sprint(b, a)
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(values) != 2 || values[0] != "a1" || values[1] != "b2" {
+		t.Fatalf("Expected values [a1 b2], got %v", values)
+	}
+}
+
+func TestZipWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	as := []Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+	}
+	bs := []Future[string]{
+		Async(func() (string, error) { return "", expectedErr }),
+	}
+	// when
+	_, err := ZipWith(ctx, as, bs, func(a int, b string) string {
+		return fmt.Sprint(b, a)
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestTiered(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var slowCalls atomic.Int32
+	failure := func() (int, error) { return 0, errors.New("test error") }
+	tiers := [][]func() (int, error){
+		{failure, failure},
+		{func() (int, error) { return 42, nil }, failure},
+		{func() (int, error) {
+			slowCalls.Add(1)
+			return 7, nil
+		}},
+	}
+	// when
+	value, err := Tiered(ctx, tiers).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if slowCalls.Load() != 0 {
+		t.Fatal("Expected the last tier not to run")
+	}
+}
+
+func TestTieredWithAllFailures(t *testing.T) {
+	// given
+	ctx := context.Background()
+	firstErr, secondErr := errors.New("first error"), errors.New("second error")
+	tiers := [][]func() (int, error){
+		{func() (int, error) { return 0, firstErr }},
+		{func() (int, error) { return 0, secondErr }},
+	}
+	// when
+	_, err := Tiered(ctx, tiers).Await(ctx)
+	// then
+	if !errors.Is(err, firstErr) || !errors.Is(err, secondErr) {
+		t.Fatalf("Expected errors %v and %v, got %v", firstErr, secondErr, err)
+	}
+}
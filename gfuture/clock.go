@@ -0,0 +1,70 @@
+package gfuture
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by the time-based helpers of the package.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer created by a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+var (
+	clockMu sync.RWMutex
+	clock   Clock = realClock{}
+)
+
+// SetClock replaces the Clock used by the package, which is the real clock by default.
+// Passing nil restores the real clock.
+// Deadlines of contexts are not affected and always follow the real clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	clock = c
+}
+
+func currentClock() Clock {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock
+}
+
+func since(start time.Time) time.Duration {
+	return currentClock().Now().Sub(start)
+}
@@ -0,0 +1,197 @@
+package gfuture
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func newFakeClock(t *testing.T) *fakeClock {
+	c := &fakeClock{now: time.Now()}
+	SetClock(c)
+	t.Cleanup(func() { SetClock(nil) })
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward, firing the timers whose deadline has passed.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if t.active && !t.deadline.After(c.now) {
+			t.active = false
+			select {
+			case t.c <- c.now:
+			default:
+			}
+		}
+	}
+}
+
+// waitForTimers blocks until n timers are active.
+func (c *fakeClock) waitForTimers(n int) {
+	for {
+		c.mu.Lock()
+		active := 0
+		for _, t := range c.timers {
+			if t.active {
+				active++
+			}
+		}
+		c.mu.Unlock()
+		if active >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	return wasActive
+}
+
+func TestSetClockWithRepeatable(t *testing.T) {
+	// given
+	ctx := context.Background()
+	clock := newFakeClock(t)
+	attempts := 0
+	factory := func() Future[int] {
+		return Async(func() (int, error) {
+			attempts++
+			if attempts == 1 {
+				return 0, errors.New("test error")
+			}
+			return 42, nil
+		})
+	}
+	result := Async(func() (int, error) {
+		return factory().Repeatable(factory).WithAttempts(2).WithBackoff(time.Hour).Await(ctx)
+	})
+	// when
+	clock.waitForTimers(1)
+	clock.Advance(time.Hour)
+	value, err := result.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestSetClockWithAwaitWithHeartbeat(t *testing.T) {
+	// given
+	ctx := context.Background()
+	clock := newFakeClock(t)
+	future := NewFuture[int]()
+	beats := make(chan struct{}, 3)
+	result := Async(func() (int, error) {
+		return future.AwaitWithHeartbeat(ctx, time.Minute, func() {
+			beats <- struct{}{}
+		})
+	})
+	// when
+	for i := 0; i < 3; i++ {
+		clock.waitForTimers(1)
+		clock.Advance(time.Minute)
+		<-beats
+	}
+	go future.Value(42)
+	value, err := result.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestSetLeakDetection(t *testing.T) {
+	// given
+	ctx := context.Background()
+	clock := newFakeClock(t)
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+	SetLeakDetection(true)
+	defer SetLeakDetection(false)
+	future := Async(func() (int, error) {
+		return 42, nil
+	})
+	// when
+	clock.waitForTimers(1)
+	clock.Advance(LeakWarningDelay)
+	time.Sleep(10 * time.Millisecond)
+	value, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if !strings.Contains(output.String(), "has not been awaited") {
+		t.Fatalf("Expected a leak warning, got %q", output.String())
+	}
+}
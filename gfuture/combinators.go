@@ -0,0 +1,243 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// chain returns a new Future resolved with the result of fn applied to the
+// value and error of the source Future once it resolves.
+// If the context is done first, fn gets the cancellation error and the source Future is drained.
+func chain[T, U any](ctx context.Context, f Future[T], fn func(T, error) (U, error)) Future[U] {
+	return spawn(func() (U, error) {
+		value, err, resolved := f.AwaitStatus(ctx)
+		if !resolved {
+			f.drain()
+		}
+		return fn(value, err)
+	})
+}
+
+// WithContext returns a new Future that awaits the source Future using the given context,
+// and resolves with the source result or with the cancellation error of that context.
+// The source Future is taken over by the returned Future and must not be awaited afterwards.
+func (f Future[T]) WithContext(ctx context.Context) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		return value, err
+	})
+}
+
+// AsAny returns a new Future resolved with the value of the source Future boxed into any.
+// This allows futures of different types to be stored together.
+func (f Future[T]) AsAny() Future[any] {
+	return chain(context.Background(), f, func(value T, err error) (any, error) {
+		return value, err
+	})
+}
+
+// Validate returns a new Future that runs each rule in order against the value of the source Future.
+// It resolves with the error of the first failing rule, or with the value if all rules pass.
+// Rules are skipped if the source Future resolves with an error.
+func (f Future[T]) Validate(ctx context.Context, rules ...func(T) error) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		if err != nil {
+			return value, err
+		}
+
+		for _, rule := range rules {
+			if err := rule(value); err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+		return value, nil
+	})
+}
+
+// Transform returns a new Future resolved with the result of fn applied to both the
+// value and error of the source Future.
+func (f Future[T]) Transform(ctx context.Context, fn func(T, error) (T, error)) Future[T] {
+	return chain(ctx, f, fn)
+}
+
+// Stages returns a Future that threads the initial value through each stage in order,
+// passing the output of a stage as the input of the next one.
+// The first failing stage resolves the Future with its error.
+func Stages[T any](ctx context.Context, initial T, stages ...func(context.Context, T) (T, error)) Future[T] {
+	return spawn(func() (T, error) {
+		value := initial
+		for _, stage := range stages {
+			if ctx.Err() != nil {
+				var zero T
+				return zero, cancelled(ctx)
+			}
+
+			var err error
+			value, err = stage(ctx, value)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+		return value, nil
+	})
+}
+
+// Coalesce returns a new Future resolved with the first of the two futures to succeed.
+// Both futures are awaited concurrently, and it only fails if both fail, with the errors joined.
+func (f Future[T]) Coalesce(ctx context.Context, other Future[T]) Future[T] {
+	return spawn(func() (T, error) {
+		var errs []error
+		for result := range settle(ctx, []Future[T]{f, other}) {
+			if result.Err == nil {
+				return result.Value, nil
+			}
+			errs = append(errs, result.Err)
+		}
+
+		var zero T
+		return zero, errors.Join(errs...)
+	})
+}
+
+// RecoverFor returns a new Future that, when the source Future fails with an error matching
+// target as reported by errors.Is, resolves with the value returned by fn instead.
+// Any other error is propagated unchanged.
+func (f Future[T]) RecoverFor(ctx context.Context, target error, fn func() T) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		if err != nil && errors.Is(err, target) {
+			return fn(), nil
+		}
+		return value, err
+	})
+}
+
+// SplitSlice returns n futures, each resolved with the element at the same index of the
+// slice produced by the source Future.
+// Futures whose index is beyond the length of the slice resolve with ErrOutOfRange, and
+// if the source Future fails, all of them resolve with its error.
+func SplitSlice[T any](ctx context.Context, f Future[[]T], n int) []Future[T] {
+	elements := make([]Future[T], n)
+	for i := range elements {
+		elements[i] = NewFuture[T]()
+	}
+
+	go func() {
+		values, err, resolved := f.AwaitStatus(ctx)
+		if !resolved {
+			f.drain()
+		}
+
+		for i, element := range elements {
+			switch {
+			case err != nil:
+				go element.Error(err)
+			case i >= len(values):
+				go element.Error(fmt.Errorf("%w: index %d with length %d", ErrOutOfRange, i, len(values)))
+			default:
+				go element.Value(values[i])
+			}
+		}
+	}()
+	return elements
+}
+
+// RequireNonZero returns a new Future that resolves with missErr when the source Future
+// succeeds with the zero value of T.
+// T must be comparable so the value can be checked against its zero value.
+func RequireNonZero[T comparable](ctx context.Context, f Future[T], missErr error) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		var zero T
+		if err == nil && value == zero {
+			return zero, missErr
+		}
+		return value, err
+	})
+}
+
+// MapResult returns a new Future resolved with the result of onValue when the source Future
+// succeeds, or with the result of onError when it fails.
+// Exactly one of the two handlers runs.
+func MapResult[T, U any](ctx context.Context, f Future[T], onValue func(T) (U, error), onError func(error) (U, error)) Future[U] {
+	return chain(ctx, f, func(value T, err error) (U, error) {
+		if err != nil {
+			return onError(err)
+		}
+		return onValue(value)
+	})
+}
+
+// FallbackChain returns a new Future that resolves with the value of the source Future or,
+// if it fails, with the value of the first provider to succeed, trying them in order.
+// Each provider only runs if all previous attempts failed, and if every attempt fails
+// it resolves with all their errors joined.
+func (f Future[T]) FallbackChain(ctx context.Context, providers ...func() (T, error)) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		if err == nil {
+			return value, nil
+		}
+
+		errs := []error{err}
+		for _, provider := range providers {
+			if ctx.Err() != nil {
+				break
+			}
+
+			fallback := Async(provider)
+			value, err, resolved := fallback.AwaitStatus(ctx)
+			if err == nil {
+				return value, nil
+			}
+
+			errs = append(errs, err)
+			if !resolved {
+				fallback.drain()
+				break
+			}
+		}
+
+		var zero T
+		return zero, errors.Join(errs...)
+	})
+}
+
+// Ensure returns a new Future that runs validate against the value of the source Future,
+// resolving with its error if it fails or with the value otherwise.
+// Validation is skipped if the source Future resolves with an error.
+func (f Future[T]) Ensure(ctx context.Context, validate func(T) error) Future[T] {
+	return f.Validate(ctx, validate)
+}
+
+// Derive2 awaits the source Future once and returns two futures resolved with the results
+// of fa and fb applied to its value.
+// If the source Future fails, both futures resolve with its error.
+func Derive2[T, A, B any](ctx context.Context, f Future[T], fa func(T) A, fb func(T) B) (Future[A], Future[B]) {
+	futureA, futureB := NewFuture[A](), NewFuture[B]()
+	go func() {
+		value, err, resolved := f.AwaitStatus(ctx)
+		if !resolved {
+			f.drain()
+		}
+
+		if err != nil {
+			go futureA.Error(err)
+			go futureB.Error(err)
+			return
+		}
+
+		go futureA.Value(fa(value))
+		go futureB.Value(fb(value))
+	}()
+	return futureA, futureB
+}
+
+// Audit returns a new Future that passes the Result of the source Future to sink,
+// then resolves with the same value and error.
+// The sink runs on the goroutine awaiting the source Future.
+func (f Future[T]) Audit(ctx context.Context, sink func(Result[T])) Future[T] {
+	return chain(ctx, f, func(value T, err error) (T, error) {
+		sink(Result[T]{Value: value, Err: err})
+		return value, err
+	})
+}
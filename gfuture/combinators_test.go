@@ -0,0 +1,614 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithContext(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := Async(func() (int, error) {
+		return 42, nil
+	})
+	// when
+	value, err := future.WithContext(ctx).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestWithContextCancelled(t *testing.T) {
+	// given
+	bound, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	future := Async(func() (int, error) {
+		time.Sleep(time.Second)
+		return 42, nil
+	})
+	// when
+	value, err := future.WithContext(bound).Await(context.Background())
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+}
+
+func TestWithContextDrainsSource(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	source := NewFuture[int]()
+	delivered := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		source.Value(42)
+		close(delivered)
+	}()
+	// when
+	_, err := source.WithContext(ctx).Await(context.Background())
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the source future to be drained")
+	}
+}
+
+func TestAsAny(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[any]{
+		Async(func() (int, error) { return 42, nil }).AsAny(),
+		Async(func() (string, error) { return "", expectedErr }).AsAny(),
+	}
+	// when
+	first, firstErr := futures[0].Await(ctx)
+	_, secondErr := futures[1].Await(ctx)
+	// then
+	if firstErr != nil {
+		t.Fatalf("Unexpected error: %v", firstErr)
+	}
+
+	if first != 42 {
+		t.Fatalf("Expected value 42, got %v", first)
+	}
+
+	if secondErr != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, secondErr)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// given
+	ctx := context.Background()
+	positive := func(v int) error {
+		if v <= 0 {
+			return errors.New("not positive")
+		}
+		return nil
+	}
+	even := func(v int) error {
+		if v%2 != 0 {
+			return errors.New("not even")
+		}
+		return nil
+	}
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).Validate(ctx, positive, even).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestValidateWithFailingRule(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	called := false
+	failing := func(v int) error { return expectedErr }
+	skipped := func(v int) error {
+		called = true
+		return nil
+	}
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).Validate(ctx, failing, skipped).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+
+	if called {
+		t.Fatal("Expected rules after the failing one to be skipped")
+	}
+}
+
+func TestTransformErrorIntoValue(t *testing.T) {
+	// given
+	ctx := context.Background()
+	// when
+	value, err := Async(func() (int, error) {
+		return 0, errors.New("test error")
+	}).Transform(ctx, func(v int, err error) (int, error) {
+		if err != nil {
+			return 42, nil
+		}
+		return v, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestTransformValueIntoError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).Transform(ctx, func(v int, err error) (int, error) {
+		return 0, expectedErr
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+}
+
+func TestStages(t *testing.T) {
+	// given
+	ctx := context.Background()
+	double := func(ctx context.Context, v int) (int, error) { return v * 2, nil }
+	increment := func(ctx context.Context, v int) (int, error) { return v + 1, nil }
+	// when
+	value, err := Stages(ctx, 20, double, increment, increment).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestStagesWithFailingStage(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	called := false
+	failing := func(ctx context.Context, v int) (int, error) { return 0, expectedErr }
+	skipped := func(ctx context.Context, v int) (int, error) {
+		called = true
+		return v, nil
+	}
+	// when
+	_, err := Stages(ctx, 1, failing, skipped).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if called {
+		t.Fatal("Expected stages after the failing one to be skipped")
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	// given
+	ctx := context.Background()
+	failing := Async(func() (int, error) {
+		return 0, errors.New("test error")
+	})
+	slow := Async(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 42, nil
+	})
+	// when
+	value, err := failing.Coalesce(ctx, slow).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestCoalesceWithBothFailing(t *testing.T) {
+	// given
+	ctx := context.Background()
+	firstErr := errors.New("first error")
+	secondErr := errors.New("second error")
+	first := Async(func() (int, error) { return 0, firstErr })
+	second := Async(func() (int, error) { return 0, secondErr })
+	// when
+	_, err := first.Coalesce(ctx, second).Await(ctx)
+	// then
+	if !errors.Is(err, firstErr) || !errors.Is(err, secondErr) {
+		t.Fatalf("Expected errors %v and %v, got %v", firstErr, secondErr, err)
+	}
+}
+
+func TestRecoverFor(t *testing.T) {
+	// given
+	ctx := context.Background()
+	target := errors.New("not found")
+	// when
+	value, err := Async(func() (int, error) {
+		return 0, fmt.Errorf("lookup: %w", target)
+	}).RecoverFor(ctx, target, func() int {
+		return 42
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestRecoverForWithOtherError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	target := errors.New("not found")
+	expectedErr := errors.New("test error")
+	// when
+	_, err := Async(func() (int, error) {
+		return 0, expectedErr
+	}).RecoverFor(ctx, target, func() int {
+		return 42
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestSplitSlice(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := Async(func() ([]int, error) {
+		return []int{1, 2}, nil
+	})
+	// when
+	elements := SplitSlice(ctx, source, 3)
+	second, secondErr := elements[1].Await(ctx)
+	first, firstErr := elements[0].Await(ctx)
+	_, thirdErr := elements[2].Await(ctx)
+	// then
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", firstErr, secondErr)
+	}
+
+	if first != 1 || second != 2 {
+		t.Fatalf("Expected values 1 and 2, got %v and %v", first, second)
+	}
+
+	if !errors.Is(thirdErr, ErrOutOfRange) {
+		t.Fatalf("Expected error %v, got %v", ErrOutOfRange, thirdErr)
+	}
+}
+
+func TestSplitSliceWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	source := Async(func() ([]int, error) {
+		return nil, expectedErr
+	})
+	// when
+	elements := SplitSlice(ctx, source, 2)
+	// then
+	for _, element := range elements {
+		if _, err := element.Await(ctx); err != expectedErr {
+			t.Fatalf("Expected error %v, got %v", expectedErr, err)
+		}
+	}
+}
+
+func TestRequireNonZero(t *testing.T) {
+	// given
+	ctx := context.Background()
+	missErr := errors.New("not found")
+	source := Async(func() (string, error) {
+		return "value", nil
+	})
+	// when
+	value, err := RequireNonZero(ctx, source, missErr).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != "value" {
+		t.Fatalf("Expected value value, got %v", value)
+	}
+}
+
+func TestRequireNonZeroWithZeroValue(t *testing.T) {
+	// given
+	ctx := context.Background()
+	missErr := errors.New("not found")
+	source := Async(func() (string, error) {
+		return "", nil
+	})
+	// when
+	_, err := RequireNonZero(ctx, source, missErr).Await(ctx)
+	// then
+	if err != missErr {
+		t.Fatalf("Expected error %v, got %v", missErr, err)
+	}
+}
+
+func TestMapResultWithValue(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := Async(func() (int, error) {
+		return 42, nil
+	})
+	// when
+	value, err := MapResult(ctx, source, func(v int) (string, error) {
+		return fmt.Sprint(v), nil
+	}, func(err error) (string, error) {
+		return "", fmt.Errorf("unexpected: %w", err)
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != "42" {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestMapResultWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := Async(func() (int, error) {
+		return 0, errors.New("test error")
+	})
+	// when
+	value, err := MapResult(ctx, source, func(v int) (string, error) {
+		return fmt.Sprint(v), nil
+	}, func(err error) (string, error) {
+		return "fallback", nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != "fallback" {
+		t.Fatalf("Expected value fallback, got %v", value)
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	// given
+	ctx := context.Background()
+	called := false
+	source := Async(func() (int, error) {
+		return 0, errors.New("cache miss")
+	})
+	// when
+	value, err := source.FallbackChain(ctx,
+		func() (int, error) { return 0, errors.New("replica down") },
+		func() (int, error) { return 42, nil },
+		func() (int, error) {
+			called = true
+			return 1, nil
+		},
+	).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if called {
+		t.Fatal("Expected providers after the first success to be skipped")
+	}
+}
+
+func TestFallbackChainWithAllFailing(t *testing.T) {
+	// given
+	ctx := context.Background()
+	firstErr := errors.New("first error")
+	secondErr := errors.New("second error")
+	source := Async(func() (int, error) {
+		return 0, firstErr
+	})
+	// when
+	_, err := source.FallbackChain(ctx, func() (int, error) {
+		return 0, secondErr
+	}).Await(ctx)
+	// then
+	if !errors.Is(err, firstErr) || !errors.Is(err, secondErr) {
+		t.Fatalf("Expected errors %v and %v, got %v", firstErr, secondErr, err)
+	}
+}
+
+func TestFallbackChainWithCancelledProviderError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := Async(func() (int, error) {
+		return 0, fmt.Errorf("upstream: %w", ErrAwaitCancelled)
+	})
+	// when
+	value, err := source.FallbackChain(ctx,
+		func() (int, error) { return 0, fmt.Errorf("replica: %w", ErrAwaitCancelled) },
+		func() (int, error) { return 42, nil },
+	).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("invalid range")
+	type interval struct{ from, to int }
+	// when
+	_, err := Async(func() (interval, error) {
+		return interval{from: 10, to: 5}, nil
+	}).Ensure(ctx, func(i interval) error {
+		if i.from > i.to {
+			return expectedErr
+		}
+		return nil
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestEnsureWithSourceError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	called := false
+	// when
+	_, err := Async(func() (int, error) {
+		return 0, expectedErr
+	}).Ensure(ctx, func(int) error {
+		called = true
+		return nil
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if called {
+		t.Fatal("Expected validation to be skipped")
+	}
+}
+
+func TestDerive2(t *testing.T) {
+	// given
+	ctx := context.Background()
+	source := Async(func() (int, error) {
+		return 21, nil
+	})
+	// when
+	doubled, text := Derive2(ctx, source, func(v int) int {
+		return v * 2
+	}, func(v int) string {
+		return fmt.Sprint(v)
+	})
+	textValue, textErr := text.Await(ctx)
+	doubledValue, doubledErr := doubled.Await(ctx)
+	// then
+	if doubledErr != nil || textErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", doubledErr, textErr)
+	}
+
+	if doubledValue != 42 || textValue != "21" {
+		t.Fatalf("Expected values 42 and 21, got %v and %v", doubledValue, textValue)
+	}
+}
+
+func TestDerive2WithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	source := Async(func() (int, error) {
+		return 0, expectedErr
+	})
+	// when
+	doubled, text := Derive2(ctx, source, func(v int) int {
+		return v * 2
+	}, func(v int) string {
+		return fmt.Sprint(v)
+	})
+	_, doubledErr := doubled.Await(ctx)
+	_, textErr := text.Await(ctx)
+	// then
+	if doubledErr != expectedErr || textErr != expectedErr {
+		t.Fatalf("Expected error %v, got %v and %v", expectedErr, doubledErr, textErr)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	var audited []Result[int]
+	sink := func(result Result[int]) {
+		audited = append(audited, result)
+	}
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).Audit(ctx, sink).Await(ctx)
+	_, failure := Async(func() (int, error) {
+		return 0, expectedErr
+	}).Audit(ctx, sink).Await(ctx)
+	// then
+	if err != nil || value != 42 {
+		t.Fatalf("Expected value 42, got %v, %v", value, err)
+	}
+
+	if failure != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, failure)
+	}
+
+	if len(audited) != 2 || audited[0].Value != 42 || audited[1].Err != expectedErr {
+		t.Fatalf("Unexpected audited results %v", audited)
+	}
+}
@@ -22,13 +22,52 @@ package gfuture
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// ErrAwaitCancelled is returned when the context is done before the Future resolves.
+// The returned error also wraps the cancellation cause of the context, which is the context
+// error unless a cause was set with context.WithCancelCause or similar.
+var ErrAwaitCancelled = errors.New("gfuture: await cancelled")
+
+// ErrNoFuture is returned when a combinator has no Future to take a result from.
+var ErrNoFuture = errors.New("gfuture: no future received")
+
+// ErrOutOfRange is returned when an element is requested beyond the length of a resolved slice.
+var ErrOutOfRange = errors.New("gfuture: index out of range")
+
+// ErrNoQuorum is returned when no value is agreed on by enough futures.
+var ErrNoQuorum = errors.New("gfuture: no quorum reached")
+
+// ErrNoMatch is returned when no Future resolves with a value meeting the expected condition.
+var ErrNoMatch = errors.New("gfuture: no future matched")
+
+// ErrCircuitOpen is returned when a Breaker refuses to run a provider.
+var ErrCircuitOpen = errors.New("gfuture: circuit open")
+
+// ErrAlreadyConsumed is returned when ConsumeOnce is called again for the same Once.
+var ErrAlreadyConsumed = errors.New("gfuture: future already consumed")
+
+// ErrCacheMiss is returned when a key is not found in a Cache.
+var ErrCacheMiss = errors.New("gfuture: cache miss")
+
 type payload[T any] struct {
 	val T     // The value of the payload.
 	err error // The error associated with the payload, if any.
 }
 
+// Result holds the value and error of a resolved Future.
+type Result[T any] struct {
+	Value T     // The value of the result.
+	Err   error // The error of the result, if any.
+}
+
 // Future is a generic type representing a future value that will be available later.
 type Future[T any] chan payload[T]
 
@@ -37,21 +76,135 @@ func NewFuture[T any]() Future[T] {
 	return make(chan payload[T])
 }
 
+// Promise creates a Future together with the functions that resolve it with a value or reject it
+// with an error. Only the first call to either function settles the Future, later calls are ignored.
+// Neither function blocks, even if the Future is never awaited.
+func Promise[T any]() (Future[T], func(T), func(error)) {
+	f := make(Future[T], 1)
+	var once sync.Once
+	complete := func(p payload[T]) {
+		once.Do(func() {
+			f <- p
+			close(f)
+		})
+	}
+	resolve := func(value T) { complete(payload[T]{val: value}) }
+	reject := func(err error) { complete(payload[T]{err: err}) }
+	return f, resolve, reject
+}
+
 // Async creates a Future and executes the provided function asynchronously.
 // The result of the function is resolved into the Future.
+// The function runs on the worker pool when one is set with SetWorkerPoolSize.
 func Async[T any](provider func() (T, error)) Future[T] {
+	if p := pool.Load(); p != nil {
+		f := make(Future[T], 1)
+		p.submit(func() {
+			value, err := provider()
+			f <- payload[T]{val: value, err: err}
+			close(f)
+		})
+		return f
+	}
+	return spawn(provider)
+}
+
+// spawn behaves like Async, but always runs the function on its own goroutine.
+// It is used for functions that await other futures, which could otherwise wait
+// forever for a worker held by themselves.
+func spawn[T any](fn func() (T, error)) Future[T] {
 	f := NewFuture[T]()
 	go func() {
-		f.Resolve(provider())
+		f.Resolve(fn())
+	}()
+	return f
+}
+
+// AsyncValue2 creates a Future and executes the provided function, which cannot fail, asynchronously.
+// The value returned by the function is resolved into the Future.
+func AsyncValue2[T any](provider func() T) Future[T] {
+	return Async(func() (T, error) {
+		return provider(), nil
+	})
+}
+
+// AsyncWithCopy creates a Future and executes the provided function asynchronously,
+// resolving the Future with a copy of the value made by clone.
+// This keeps the awaiter from sharing data the provider still holds a reference to.
+func AsyncWithCopy[T any](provider func() (T, error), clone func(T) T) Future[T] {
+	return Async(func() (T, error) {
+		value, err := provider()
+		if err != nil {
+			return value, err
+		}
+		return clone(value), nil
+	})
+}
+
+// AsyncWithCleanup creates a Future and executes the provider asynchronously with the given context.
+// If the context is done by the time the provider returns a value, cleanup is called with the value
+// so that it can release its resources, and the Future resolves with the cancellation error instead.
+// Provider errors are always delivered as they are, and resolving never blocks on a consumer.
+func AsyncWithCleanup[T any](ctx context.Context, provider func(context.Context) (T, error), cleanup func(T)) Future[T] {
+	f := make(Future[T], 1)
+	go func() {
+		defer close(f)
+		value, err := provider(ctx)
+		if err == nil && ctx.Err() != nil {
+			cleanup(value)
+			f <- payload[T]{err: cancelled(ctx)}
+			return
+		}
+		f <- payload[T]{val: value, err: err}
 	}()
 	return f
 }
 
+// Check creates a Future that runs fn asynchronously and resolves with true if it returns true,
+// or with false and failErr otherwise.
+// If the context is done first, it resolves with the cancellation error.
+func Check(ctx context.Context, fn func() bool, failErr error) Future[bool] {
+	return Async(func() (bool, error) {
+		if !fn() {
+			return false, failErr
+		}
+		return true, nil
+	}).WithContext(ctx)
+}
+
+// LeakWarningDelay is how long a resolved Future may wait to be awaited before
+// a warning is logged, when leak detection is enabled.
+const LeakWarningDelay = time.Minute
+
+var leakDetection atomic.Bool
+
+// SetLeakDetection enables or disables leak detection, which is disabled by default.
+// When enabled, a warning is logged for every Future that is resolved but not awaited
+// within LeakWarningDelay, since its producer stays blocked until it is.
+func SetLeakDetection(enabled bool) {
+	leakDetection.Store(enabled)
+}
+
 func (f Future[T]) sendAndClose(p payload[T]) {
-	f <- p
+	if leakDetection.Load() {
+		f.sendWatched(p)
+	} else {
+		f <- p
+	}
 	close(f)
 }
 
+func (f Future[T]) sendWatched(p payload[T]) {
+	timer := currentClock().NewTimer(LeakWarningDelay)
+	defer timer.Stop()
+	select {
+	case f <- p:
+	case <-timer.C():
+		log.Printf("gfuture: future of type %T resolved %v ago has not been awaited", f, LeakWarningDelay)
+		f <- p
+	}
+}
+
 // Resolve sets the value and error of the Future and closes it.
 func (f Future[T]) Resolve(value T, err error) {
 	f.sendAndClose(payload[T]{val: value, err: err})
@@ -67,20 +220,281 @@ func (f Future[T]) Error(err error) {
 	f.sendAndClose(payload[T]{err: err})
 }
 
+// ResolveFromSelect resolves the Future with the first Result received from any of the channels.
+// Channels that are closed without a Result are skipped, and if all of them are closed
+// the Future resolves with ErrNoFuture.
+// The remaining channels are ignored once a Result is received, and no goroutine is left listening on them.
+func (f Future[T]) ResolveFromSelect(cases ...<-chan Result[T]) {
+	selectCases := make([]reflect.SelectCase, len(cases))
+	for i, ch := range cases {
+		selectCases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+
+	for open := len(cases); open > 0; open-- {
+		chosen, received, ok := reflect.Select(selectCases)
+		if ok {
+			result := received.Interface().(Result[T])
+			f.Resolve(result.Value, result.Err)
+			return
+		}
+		selectCases[chosen].Chan = reflect.Value{}
+	}
+	f.Error(ErrNoFuture)
+}
+
+// ResolveAll resolves each Future with the value and error found at the same index.
+// It blocks until every Future has been resolved.
+// It panics if the slices do not have the same length.
+func ResolveAll[T any](futures []Future[T], values []T, errs []error) {
+	if len(futures) != len(values) || len(futures) != len(errs) {
+		panic(fmt.Sprintf("gfuture: ResolveAll called with %d futures, %d values and %d errors", len(futures), len(values), len(errs)))
+	}
+
+	var wg sync.WaitGroup
+	for i, f := range futures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Resolve(values[i], errs[i])
+		}()
+	}
+	wg.Wait()
+}
+
 // Await waits for the Future to resolve and returns the value and error.
+// If the context is done first, the error wraps both ErrAwaitCancelled and the context error.
 func (f Future[T]) Await(ctx context.Context) (T, error) {
 	select {
 	case payload := <-f:
 		return payload.val, payload.err
 	case <-ctx.Done():
 		var zero T
-		return zero, ctx.Err()
+		return zero, cancelled(ctx)
+	}
+}
+
+// AwaitStatus waits for the Future to resolve and returns the value, the error and
+// whether the result came from the Future resolving (true) or from the context being done (false).
+func (f Future[T]) AwaitStatus(ctx context.Context) (T, error, bool) {
+	select {
+	case payload := <-f:
+		return payload.val, payload.err, true
+	case <-ctx.Done():
+		var zero T
+		return zero, cancelled(ctx), false
+	}
+}
+
+// AwaitDiagnostic waits for the Future to resolve and returns the value, the error and
+// how long the wait took, whether the Future resolved or the context was done first.
+func (f Future[T]) AwaitDiagnostic(ctx context.Context) (T, error, time.Duration) {
+	start := currentClock().Now()
+	value, err := f.Await(ctx)
+	return value, err, since(start)
+}
+
+// AwaitWithHeartbeat waits for the Future to resolve, calling beat every interval while waiting.
+// The heartbeat stops as soon as the Future resolves or the context is done.
+func (f Future[T]) AwaitWithHeartbeat(ctx context.Context, interval time.Duration, beat func()) (T, error) {
+	timer := currentClock().NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case payload := <-f:
+			return payload.val, payload.err
+		case <-timer.C():
+			beat()
+			timer.Reset(interval)
+		case <-ctx.Done():
+			var zero T
+			return zero, cancelled(ctx)
+		}
+	}
+}
+
+// AwaitAtLeast waits for the Future to resolve, but never returns before d has elapsed.
+// If the context is done first, it returns right away with the cancellation error.
+func (f Future[T]) AwaitAtLeast(ctx context.Context, d time.Duration) (T, error) {
+	timer := currentClock().NewTimer(d)
+	defer timer.Stop()
+	value, err, resolved := f.AwaitStatus(ctx)
+	if !resolved {
+		return value, err
+	}
+
+	select {
+	case <-timer.C():
+		return value, err
+	case <-ctx.Done():
+		var zero T
+		return zero, cancelled(ctx)
+	}
+}
+
+// AwaitUntil waits for the Future to resolve and returns the first value accepted by accept,
+// or the final value if none is accepted.
+// A Future resolves with a single value, which is both the first and the final one,
+// so for now it behaves like Await.
+func (f Future[T]) AwaitUntil(ctx context.Context, accept func(T) bool) (T, error) {
+	return f.Await(ctx)
+}
+
+// AwaitLocked acquires mu, waits for the Future to resolve and releases mu before returning.
+// The lock is held for the whole wait, so other users of mu are blocked until the Future resolves
+// or the context is done.
+func (f Future[T]) AwaitLocked(ctx context.Context, mu sync.Locker) (T, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return f.Await(ctx)
+}
+
+// AsProbe waits up to timeout for the Future to resolve and returns its error, discarding the value,
+// so it can back a health probe of the form func() error.
+// If the timeout expires or the context is done first, the Future is drained and the
+// cancellation error is returned.
+func (f Future[T]) AsProbe(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err, resolved := f.AwaitStatus(ctx)
+	if !resolved {
+		f.drain()
+	}
+	return err
+}
+
+// ValueOrLog waits for the Future to resolve and returns its value.
+// On error, it passes the error to logger and returns the zero value.
+func (f Future[T]) ValueOrLog(ctx context.Context, logger func(error)) T {
+	value, err := f.Await(ctx)
+	if err != nil {
+		logger(err)
+		var zero T
+		return zero
+	}
+	return value
+}
+
+func cancelled(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrAwaitCancelled, context.Cause(ctx))
+}
+
+// drain consumes the Future in the background, discarding its result,
+// so the producer is not blocked forever.
+func (f Future[T]) drain() {
+	go f.Await(context.Background())
+}
+
+func drainAll[T any](futures []Future[T]) {
+	for _, f := range futures {
+		f.drain()
 	}
 }
 
 // Then executes the provided consumer function with the value and error of the Future once resolved.
+// If the context is already done, the consumer is called right away with the cancellation error.
 func (f Future[T]) Then(ctx context.Context, consumer func(T, error)) {
+	if ctx.Err() != nil {
+		var zero T
+		consumer(zero, cancelled(ctx))
+		return
+	}
+
 	go func() {
 		consumer(f.Await(ctx))
 	}()
 }
+
+// ThenSafe behaves like Then, but recovers any panic raised by the consumer and passes
+// the recovered value to onPanic.
+// If onPanic is nil, the panic is logged and swallowed.
+func (f Future[T]) ThenSafe(ctx context.Context, consumer func(T, error), onPanic func(any)) {
+	f.Then(ctx, func(value T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if onPanic == nil {
+					log.Printf("gfuture: recovered panic in consumer: %v", r)
+					return
+				}
+				onPanic(r)
+			}
+		}()
+		consumer(value, err)
+	})
+}
+
+// ThenWithin executes onResult with the value and error of the Future if it resolves within d,
+// otherwise it executes onTimeout and drains the Future.
+// Exactly one of the two callbacks runs; if the context is done first, onResult receives the cancellation error.
+func (f Future[T]) ThenWithin(ctx context.Context, d time.Duration, onResult func(T, error), onTimeout func()) {
+	go func() {
+		timer := currentClock().NewTimer(d)
+		defer timer.Stop()
+		select {
+		case payload := <-f:
+			onResult(payload.val, payload.err)
+		case <-timer.C():
+			f.drain()
+			onTimeout()
+		case <-ctx.Done():
+			var zero T
+			onResult(zero, cancelled(ctx))
+		}
+	}()
+}
+
+// OnErrorCleanup runs cleanup once the Future resolves with an error.
+// It does not run on success, nor when the context is done before the Future resolves.
+func (f Future[T]) OnErrorCleanup(ctx context.Context, cleanup func()) {
+	f.onErrorCleanup(ctx, cleanup, false)
+}
+
+// OnErrorCleanupAlways runs cleanup once the Future resolves with an error, or when
+// the context is done before the Future resolves. It does not run on success.
+func (f Future[T]) OnErrorCleanupAlways(ctx context.Context, cleanup func()) {
+	f.onErrorCleanup(ctx, cleanup, true)
+}
+
+func (f Future[T]) onErrorCleanup(ctx context.Context, cleanup func(), onCancel bool) {
+	go func() {
+		_, err, resolved := f.AwaitStatus(ctx)
+		if err != nil && (resolved || onCancel) {
+			cleanup()
+		}
+	}()
+}
+
+// Getter returns a function that awaits the Future on its first call and returns
+// the cached value and error on every later call.
+// The returned function is safe for concurrent use.
+func (f Future[T]) Getter(ctx context.Context) func() (T, error) {
+	var once sync.Once
+	var value T
+	var err error
+	return func() (T, error) {
+		once.Do(func() {
+			value, err = f.Await(ctx)
+		})
+		return value, err
+	}
+}
+
+// Once wraps a Future so that its result is handed to a single consumer.
+type Once[T any] struct {
+	future Future[T]   // The wrapped Future.
+	taken  atomic.Bool // Whether the awaiter has been handed out.
+}
+
+// Once returns a Once wrapping the Future. Consumers must share the returned Once,
+// since each call creates a new one.
+func (f Future[T]) Once() *Once[T] {
+	return &Once[T]{future: f}
+}
+
+// ConsumeOnce returns a function that awaits the wrapped Future the first time it is called,
+// and ErrAlreadyConsumed on every later call.
+func (o *Once[T]) ConsumeOnce() (func(ctx context.Context) (T, error), error) {
+	if o.taken.Swap(true) {
+		return nil, ErrAlreadyConsumed
+	}
+	return o.future.Await, nil
+}
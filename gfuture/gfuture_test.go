@@ -3,6 +3,8 @@ package gfuture
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 )
@@ -14,6 +16,40 @@ func TestNewFuture(t *testing.T) {
 	}
 }
 
+func TestPromise(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future, resolve, reject := Promise[int]()
+	// when
+	resolve(42)
+	resolve(7)
+	reject(errors.New("test error"))
+	value, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestPromiseWithReject(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	future, resolve, reject := Promise[int]()
+	// when
+	reject(expectedErr)
+	resolve(42)
+	_, err := future.Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
 func TestResolve(t *testing.T) {
 	// given
 	ctx := context.Background()
@@ -84,6 +120,191 @@ func TestAsync(t *testing.T) {
 	}
 }
 
+func TestAsyncValue2(t *testing.T) {
+	// given
+	ctx := context.Background()
+	// when
+	value, err := AsyncValue2(func() int {
+		return 42
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestAsyncWithCopy(t *testing.T) {
+	// given
+	ctx := context.Background()
+	shared := []int{1, 2, 3}
+	// when
+	value, err := AsyncWithCopy(func() ([]int, error) {
+		return shared, nil
+	}, func(v []int) []int {
+		return append([]int(nil), v...)
+	}).Await(ctx)
+	value[0] = 42
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if shared[0] != 1 {
+		t.Fatalf("Expected shared data to be unchanged, got %v", shared)
+	}
+}
+
+func TestAsyncWithCleanup(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cleaned := false
+	// when
+	value, err := AsyncWithCleanup(ctx, func(ctx context.Context) (int, error) {
+		return 42, nil
+	}, func(int) {
+		cleaned = true
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if cleaned {
+		t.Fatal("Expected cleanup not to run")
+	}
+}
+
+func TestAsyncWithCleanupWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	cleaned := make(chan int)
+	// when
+	_, err := AsyncWithCleanup(ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		return 42, nil
+	}, func(value int) {
+		cleaned <- value
+	}).Await(ctx)
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	if value := <-cleaned; value != 42 {
+		t.Fatalf("Expected cleanup with value 42, got %v", value)
+	}
+}
+
+func TestAsyncWithCleanupAwaitedAfterCancellation(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cleaned := make(chan int, 1)
+	future := AsyncWithCleanup(ctx, func(ctx context.Context) (int, error) {
+		return 42, nil
+	}, func(value int) {
+		cleaned <- value
+	})
+	// when
+	value, err := future.Await(context.Background())
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+
+	if value := <-cleaned; value != 42 {
+		t.Fatalf("Expected cleanup with value 42, got %v", value)
+	}
+}
+
+func TestAsyncWithCleanupDoesNotBlockWithoutConsumer(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cleaned := make(chan struct{})
+	// when
+	future := AsyncWithCleanup(ctx, func(ctx context.Context) (int, error) {
+		return 42, nil
+	}, func(int) {
+		close(cleaned)
+	})
+	<-cleaned
+	// then
+	select {
+	case <-future:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the Future to be resolved without a consumer")
+	}
+}
+
+func TestAsyncWithCleanupWithProviderErrorAfterCancellation(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expectedErr := errors.New("test error")
+	cleaned := false
+	future := AsyncWithCleanup(ctx, func(ctx context.Context) (int, error) {
+		return 0, expectedErr
+	}, func(int) {
+		cleaned = true
+	})
+	// when
+	_, err := future.Await(context.Background())
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if cleaned {
+		t.Fatal("Expected cleanup not to run")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// given
+	ctx := context.Background()
+	failErr := errors.New("not ready")
+	// when
+	ready, err := Check(ctx, func() bool { return true }, failErr).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !ready {
+		t.Fatal("Expected true, got false")
+	}
+}
+
+func TestCheckWithFailure(t *testing.T) {
+	// given
+	ctx := context.Background()
+	failErr := errors.New("not ready")
+	// when
+	ready, err := Check(ctx, func() bool { return false }, failErr).Await(ctx)
+	// then
+	if err != failErr {
+		t.Fatalf("Expected error %v, got %v", failErr, err)
+	}
+
+	if ready {
+		t.Fatal("Expected false, got true")
+	}
+}
+
 func TestAwaitWithTimeout(t *testing.T) {
 	// given
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
@@ -94,13 +315,302 @@ func TestAwaitWithTimeout(t *testing.T) {
 		return 42, nil
 	}).Await(ctx)
 	// then
-	if err == nil {
-		t.Fatal("Expected context deadline exceeded error, got nil")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", err)
+	}
+
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+}
+
+func TestAwaitStatus(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	// when
+	_, err, resolved := Async(func() (int, error) {
+		return 0, expectedErr
+	}).AwaitStatus(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if !resolved {
+		t.Fatal("Expected the result to come from the future")
+	}
+}
+
+func TestAwaitStatusWithTimeout(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	// when
+	value, err, resolved := Async(func() (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		return 42, nil
+	}).AwaitStatus(ctx)
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", err)
+	}
+
+	if resolved {
+		t.Fatal("Expected the result to come from the context")
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+}
+
+func TestAwaitDiagnostic(t *testing.T) {
+	// given
+	ctx := context.Background()
+	// when
+	value, err, elapsed := Async(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 42, nil
+	}).AwaitDiagnostic(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if elapsed < 100*time.Millisecond {
+		t.Fatalf("Expected to wait at least 100ms, got %v", elapsed)
+	}
+}
+
+func TestAwaitWithCancelCause(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancelCause(context.Background())
+	expectedCause := errors.New("test cause")
+	cancel(expectedCause)
+	// when
+	_, err := NewFuture[int]().Await(ctx)
+	// then
+	if !errors.Is(err, expectedCause) {
+		t.Fatalf("Expected error %v, got %v", expectedCause, err)
+	}
+
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
+
+func TestAwaitWithHeartbeat(t *testing.T) {
+	// given
+	ctx := context.Background()
+	beats := 0
+	// when
+	value, err := Async(func() (int, error) {
+		time.Sleep(250 * time.Millisecond)
+		return 42, nil
+	}).AwaitWithHeartbeat(ctx, 100*time.Millisecond, func() {
+		beats++
+	})
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if beats != 2 {
+		t.Fatalf("Expected 2 heartbeats, got %v", beats)
+	}
+}
+
+func TestAwaitAtLeast(t *testing.T) {
+	// given
+	ctx := context.Background()
+	start := time.Now()
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).AwaitAtLeast(ctx, 100*time.Millisecond)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("Expected to wait at least 100ms, waited %v", elapsed)
+	}
+}
+
+func TestAwaitAtLeastWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// when
+	_, err := Async(func() (int, error) {
+		return 42, nil
+	}).AwaitAtLeast(ctx, time.Second)
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
+
+func TestAwaitUntil(t *testing.T) {
+	// given
+	ctx := context.Background()
+	// when
+	value, err := Async(func() (int, error) {
+		return 42, nil
+	}).AwaitUntil(ctx, func(v int) bool {
+		return v > 100
+	})
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestAwaitLocked(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var mu sync.Mutex
+	future := Async(func() (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 42, nil
+	})
+	locked := make(chan bool)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		acquired := mu.TryLock()
+		if acquired {
+			mu.Unlock()
+		}
+		locked <- !acquired
+	}()
+	// when
+	value, err := future.AwaitLocked(ctx, &mu)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if !<-locked {
+		t.Fatal("Expected the lock to be held while awaiting")
+	}
+
+	if !mu.TryLock() {
+		t.Fatal("Expected the lock to be released")
+	}
+}
+
+func TestAsProbe(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := Async(func() (int, error) {
+		return 42, nil
+	})
+	// when
+	err := future.AsProbe(ctx, time.Second)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
 	}
+}
+
+func TestAsProbeWithTimeout(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := Async(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 42, nil
+	})
+	// when
+	err := future.AsProbe(ctx, 10*time.Millisecond)
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestValueOrLog(t *testing.T) {
+	// given
+	ctx := context.Background()
+	logged := false
+	// when
+	value := Async(func() (int, error) {
+		return 42, nil
+	}).ValueOrLog(ctx, func(error) {
+		logged = true
+	})
+	// then
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if logged {
+		t.Fatal("Expected logger not to be called")
+	}
+}
 
+func TestValueOrLogWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	var logged error
+	// when
+	value := Async(func() (int, error) {
+		return 42, expectedErr
+	}).ValueOrLog(ctx, func(err error) {
+		logged = err
+	})
+	// then
 	if value != 0 {
 		t.Fatalf("Expected value 0, got %v", value)
 	}
+
+	if logged != expectedErr {
+		t.Fatalf("Expected logged error %v, got %v", expectedErr, logged)
+	}
+}
+
+func TestAwaitWithProviderError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	// when
+	_, err := Async(func() (int, error) {
+		return 0, expectedErr
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected provider error not to be %v", ErrAwaitCancelled)
+	}
 }
 
 func TestThen(t *testing.T) {
@@ -145,3 +655,311 @@ func TestThenWithError(t *testing.T) {
 		t.Fatalf("Expected value 0, got %v", value)
 	}
 }
+
+func TestResolveFromSelect(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := NewFuture[int]()
+	slow := make(chan Result[int])
+	fast := make(chan Result[int], 1)
+	closed := make(chan Result[int])
+	close(closed)
+	fast <- Result[int]{Value: 42}
+	// when
+	go future.ResolveFromSelect(slow, closed, fast)
+	value, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestResolveFromSelectWithClosedChannels(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := NewFuture[int]()
+	closed := make(chan Result[int])
+	close(closed)
+	// when
+	go future.ResolveFromSelect(closed)
+	_, err := future.Await(ctx)
+	// then
+	if err != ErrNoFuture {
+		t.Fatalf("Expected error %v, got %v", ErrNoFuture, err)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	futures := []Future[int]{NewFuture[int](), NewFuture[int]()}
+	// when
+	go ResolveAll(futures, []int{42, 0}, []error{nil, expectedErr})
+	second, secondErr := futures[1].Await(ctx)
+	first, firstErr := futures[0].Await(ctx)
+	// then
+	if firstErr != nil {
+		t.Fatalf("Unexpected error: %v", firstErr)
+	}
+
+	if first != 42 {
+		t.Fatalf("Expected value 42, got %v", first)
+	}
+
+	if secondErr != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, secondErr)
+	}
+
+	if second != 0 {
+		t.Fatalf("Expected value 0, got %v", second)
+	}
+}
+
+func TestResolveAllWithMismatchedLengths(t *testing.T) {
+	// given
+	futures := []Future[int]{NewFuture[int]()}
+	// then
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic, got none")
+		}
+	}()
+	// when
+	ResolveAll(futures, []int{1, 2}, []error{nil})
+}
+
+func TestThenSafe(t *testing.T) {
+	// given
+	ctx := context.Background()
+	recovered := make(chan any)
+	// when
+	Async(func() (int, error) {
+		return 42, nil
+	}).ThenSafe(ctx, func(value int, err error) {
+		panic("test panic")
+	}, func(r any) {
+		recovered <- r
+	})
+	r := <-recovered
+	// then
+	if r != "test panic" {
+		t.Fatalf("Expected recovered value test panic, got %v", r)
+	}
+}
+
+func TestThenSafeWithoutPanicHandler(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// when
+	NewFuture[int]().ThenSafe(ctx, func(value int, err error) {
+		panic("test panic")
+	}, nil)
+	// then the panic is swallowed
+}
+
+func TestThenWithin(t *testing.T) {
+	// given
+	ctx := context.Background()
+	results := make(chan int)
+	// when
+	Async(func() (int, error) {
+		return 42, nil
+	}).ThenWithin(ctx, time.Second, func(value int, err error) {
+		results <- value
+	}, func() {
+		t.Error("Expected onTimeout not to be called")
+	})
+	value := <-results
+	// then
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestThenWithinWithTimeout(t *testing.T) {
+	// given
+	ctx := context.Background()
+	timedOut := make(chan struct{})
+	// when
+	Async(func() (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		return 42, nil
+	}).ThenWithin(ctx, 100*time.Millisecond, func(value int, err error) {
+		t.Error("Expected onResult not to be called")
+	}, func() {
+		close(timedOut)
+	})
+	// then
+	select {
+	case <-timedOut:
+	case <-time.After(time.Second):
+		t.Fatal("Expected onTimeout to be called")
+	}
+}
+
+func TestOnErrorCleanup(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cleaned := make(chan struct{})
+	// when
+	Async(func() (int, error) {
+		return 0, errors.New("test error")
+	}).OnErrorCleanup(ctx, func() {
+		close(cleaned)
+	})
+	// then
+	select {
+	case <-cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("Expected cleanup to run")
+	}
+}
+
+func TestOnErrorCleanupWithSuccess(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cleaned := make(chan struct{})
+	// when
+	Async(func() (int, error) {
+		return 42, nil
+	}).OnErrorCleanup(ctx, func() {
+		close(cleaned)
+	})
+	// then
+	select {
+	case <-cleaned:
+		t.Fatal("Expected cleanup not to run")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOnErrorCleanupWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cleaned := make(chan struct{})
+	// when
+	NewFuture[int]().OnErrorCleanup(ctx, func() {
+		close(cleaned)
+	})
+	// then
+	select {
+	case <-cleaned:
+		t.Fatal("Expected cleanup not to run")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOnErrorCleanupWithCancelledProviderError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	cleaned := make(chan struct{})
+	// when
+	Async(func() (int, error) {
+		return 0, fmt.Errorf("upstream: %w", ErrAwaitCancelled)
+	}).OnErrorCleanup(ctx, func() {
+		close(cleaned)
+	})
+	// then
+	select {
+	case <-cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("Expected cleanup to run")
+	}
+}
+
+func TestOnErrorCleanupAlwaysWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cleaned := make(chan struct{})
+	// when
+	NewFuture[int]().OnErrorCleanupAlways(ctx, func() {
+		close(cleaned)
+	})
+	// then
+	select {
+	case <-cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("Expected cleanup to run")
+	}
+}
+
+func TestGetter(t *testing.T) {
+	// given
+	ctx := context.Background()
+	calls := 0
+	getter := Async(func() (int, error) {
+		calls++
+		return 42, nil
+	}).Getter(ctx)
+	// when
+	first, firstErr := getter()
+	second, secondErr := getter()
+	// then
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", firstErr, secondErr)
+	}
+
+	if first != 42 || second != 42 {
+		t.Fatalf("Expected values 42 and 42, got %v and %v", first, second)
+	}
+
+	if calls != 1 {
+		t.Fatalf("Expected provider to be called once, got %v", calls)
+	}
+}
+
+func TestConsumeOnce(t *testing.T) {
+	// given
+	ctx := context.Background()
+	once := Async(func() (int, error) {
+		return 42, nil
+	}).Once()
+	// when
+	consume, firstErr := once.ConsumeOnce()
+	_, secondErr := once.ConsumeOnce()
+	// then
+	if firstErr != nil {
+		t.Fatalf("Unexpected error: %v", firstErr)
+	}
+
+	if secondErr != ErrAlreadyConsumed {
+		t.Fatalf("Expected error %v, got %v", ErrAlreadyConsumed, secondErr)
+	}
+
+	value, err := consume(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestThenWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var value int
+	var err error
+	// when
+	NewFuture[int]().Then(ctx, func(v int, e error) {
+		value, err = v, e
+	})
+	// then
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled error, got %v", err)
+	}
+
+	if value != 0 {
+		t.Fatalf("Expected value 0, got %v", value)
+	}
+}
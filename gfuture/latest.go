@@ -0,0 +1,44 @@
+package gfuture
+
+import (
+	"context"
+	"sync"
+)
+
+// Latest is a value that can be set repeatedly and awaited many times.
+// Unlike a Future, it never runs out: awaiting it returns the most recently set value.
+type Latest[T any] struct {
+	mu    sync.Mutex
+	ready chan struct{} // Closed once the first value is set.
+	once  sync.Once
+	value T // The most recently set value.
+}
+
+// NewLatest creates a Latest with no value set.
+func NewLatest[T any]() *Latest[T] {
+	return &Latest[T]{ready: make(chan struct{})}
+}
+
+// Set replaces the current value and releases every caller waiting for the first one.
+func (l *Latest[T]) Set(value T) {
+	l.mu.Lock()
+	l.value = value
+	l.mu.Unlock()
+	l.once.Do(func() {
+		close(l.ready)
+	})
+}
+
+// Await returns the most recently set value, blocking only until the first value is set.
+// If the context is done first, it returns the cancellation error.
+func (l *Latest[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-l.ready:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.value, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, cancelled(ctx)
+	}
+}
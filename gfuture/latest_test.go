@@ -0,0 +1,45 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatest(t *testing.T) {
+	// given
+	ctx := context.Background()
+	latest := NewLatest[int]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		latest.Set(1)
+	}()
+	// when
+	first, firstErr := latest.Await(ctx)
+	latest.Set(2)
+	latest.Set(42)
+	second, secondErr := latest.Await(ctx)
+	third, thirdErr := latest.Await(ctx)
+	// then
+	if firstErr != nil || secondErr != nil || thirdErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v, %v", firstErr, secondErr, thirdErr)
+	}
+
+	if first != 1 || second != 42 || third != 42 {
+		t.Fatalf("Expected values 1, 42 and 42, got %v, %v and %v", first, second, third)
+	}
+}
+
+func TestLatestWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	latest := NewLatest[int]()
+	// when
+	_, err := latest.Await(ctx)
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
@@ -0,0 +1,59 @@
+package gfuture
+
+import (
+	"sync"
+	"time"
+)
+
+// flight is a shared call to a provider whose result is handed to every Future created for it.
+type flight[T any] struct {
+	done    chan struct{} // Closed once the provider has returned.
+	value   T             // The value returned by the provider.
+	err     error         // The error returned by the provider, if any.
+	expires time.Time     // When the result stops being served.
+}
+
+func (fl *flight[T]) future() Future[T] {
+	return spawn(func() (T, error) {
+		<-fl.done
+		return fl.value, fl.err
+	})
+}
+
+func (fl *flight[T]) isDone() bool {
+	select {
+	case <-fl.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cached returns a function that creates futures resolved with the cached result of the provider.
+// The provider is called on first use and again on the first call after ttl has elapsed;
+// callers arriving while a call is in flight share its result.
+// A failed call is not cached, so the next call triggers a new attempt.
+func Cached[T any](ttl time.Duration, provider func() (T, error)) func() Future[T] {
+	var mu sync.Mutex
+	var current *flight[T]
+	return func() Future[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		if current == nil || (current.isDone() && !currentClock().Now().Before(current.expires)) {
+			fl := &flight[T]{done: make(chan struct{})}
+			current = fl
+			go func() {
+				value, err := provider()
+				mu.Lock()
+				fl.value, fl.err = value, err
+				fl.expires = currentClock().Now().Add(ttl)
+				if err != nil && current == fl {
+					current = nil
+				}
+				mu.Unlock()
+				close(fl.done)
+			}()
+		}
+		return current.future()
+	}
+}
@@ -0,0 +1,84 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCached(t *testing.T) {
+	// given
+	ctx := context.Background()
+	clock := newFakeClock(t)
+	var calls atomic.Int32
+	get := Cached(time.Minute, func() (int32, error) {
+		return calls.Add(1), nil
+	})
+	// when
+	first, _ := get().Await(ctx)
+	second, _ := get().Await(ctx)
+	clock.Advance(time.Minute)
+	third, err := get().Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if first != 1 || second != 1 || third != 2 {
+		t.Fatalf("Expected values 1, 1 and 2, got %v, %v and %v", first, second, third)
+	}
+}
+
+func TestCachedWithConcurrentCallers(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	get := Cached(time.Minute, func() (int32, error) {
+		<-release
+		return calls.Add(1), nil
+	})
+	// when
+	first, second := get(), get()
+	close(release)
+	firstValue, _ := first.Await(ctx)
+	secondValue, _ := second.Await(ctx)
+	// then
+	if firstValue != 1 || secondValue != 1 {
+		t.Fatalf("Expected values 1 and 1, got %v and %v", firstValue, secondValue)
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("Expected provider to be called once, got %v", calls.Load())
+	}
+}
+
+func TestCachedWithFailedRefresh(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	var calls atomic.Int32
+	get := Cached(time.Minute, func() (int32, error) {
+		if calls.Add(1) == 1 {
+			return 0, expectedErr
+		}
+		return 42, nil
+	})
+	// when
+	_, firstErr := get().Await(ctx)
+	value, err := get().Await(ctx)
+	// then
+	if firstErr != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, firstErr)
+	}
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
@@ -0,0 +1,59 @@
+package gfuture
+
+import (
+	"context"
+)
+
+// Pipeline is a chain of asynchronous steps where each step runs with its own child
+// context of the pipeline context.
+// Cancelling the context of one step does not cancel the steps before it.
+type Pipeline[T any] struct {
+	ctx    context.Context    // The parent context of every step.
+	f      Future[T]          // The Future of the last step.
+	cancel context.CancelFunc // Cancels the context of the last step, if any.
+}
+
+// PipeIsolated creates a Pipeline starting with the given Future.
+func PipeIsolated[T any](ctx context.Context, f Future[T]) Pipeline[T] {
+	return Pipeline[T]{ctx: ctx, f: f}
+}
+
+// Step returns a Pipeline that runs fn with the value of the previous step once it succeeds.
+// fn receives a child context of the pipeline context, which is cancelled when the step completes
+// or when Cancel is called on the returned Pipeline.
+// If the previous step fails, fn is skipped and the error is propagated.
+func (p Pipeline[T]) Step(fn func(context.Context, T) (T, error)) Pipeline[T] {
+	ctx, cancel := context.WithCancel(p.ctx)
+	f := spawn(func() (T, error) {
+		defer cancel()
+		value, err, resolved := p.f.AwaitStatus(ctx)
+		if !resolved {
+			p.f.drain()
+		}
+
+		if err != nil {
+			return value, err
+		}
+		return fn(ctx, value)
+	})
+	return Pipeline[T]{ctx: p.ctx, f: f, cancel: cancel}
+}
+
+// Cancel cancels the context of the last step only, which then resolves with the cancellation error.
+// The steps before it keep running and their results are discarded.
+// It does nothing on a Pipeline without steps.
+func (p Pipeline[T]) Cancel() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// Future returns the Future of the last step of the Pipeline.
+func (p Pipeline[T]) Future() Future[T] {
+	return p.f
+}
+
+// Await waits for the last step of the Pipeline and returns its value and error.
+func (p Pipeline[T]) Await(ctx context.Context) (T, error) {
+	return p.f.Await(ctx)
+}
@@ -0,0 +1,93 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPipeIsolated(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var stepCtx context.Context
+	source := Async(func() (int, error) {
+		return 20, nil
+	})
+	// when
+	value, err := PipeIsolated(ctx, source).Step(func(ctx context.Context, v int) (int, error) {
+		stepCtx = ctx
+		return v * 2, nil
+	}).Step(func(ctx context.Context, v int) (int, error) {
+		if stepCtx.Err() == nil {
+			return 0, errors.New("expected the previous step context to be cancelled")
+		}
+		return v + 2, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestPipeIsolatedWithFailingStep(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	called := false
+	source := Async(func() (int, error) {
+		return 1, nil
+	})
+	// when
+	_, err := PipeIsolated(ctx, source).Step(func(ctx context.Context, v int) (int, error) {
+		return 0, expectedErr
+	}).Step(func(ctx context.Context, v int) (int, error) {
+		called = true
+		return v, nil
+	}).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if called {
+		t.Fatal("Expected steps after the failing one to be skipped")
+	}
+}
+
+func TestPipeIsolatedWithCancelledStep(t *testing.T) {
+	// given
+	ctx := context.Background()
+	upstreamDone := make(chan struct{})
+	source := Async(func() (int, error) {
+		return 20, nil
+	})
+	upstream := PipeIsolated(ctx, source).Step(func(ctx context.Context, v int) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		close(upstreamDone)
+		return v * 2, nil
+	})
+	downstream := upstream.Step(func(ctx context.Context, v int) (int, error) {
+		return v + 2, nil
+	})
+	// when
+	downstream.Cancel()
+	_, err := downstream.Await(ctx)
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+
+	select {
+	case <-upstreamDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the upstream step to complete")
+	}
+}
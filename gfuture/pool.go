@@ -0,0 +1,75 @@
+package gfuture
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// workerPool runs tasks on a fixed number of goroutines, queueing them while all workers are busy.
+type workerPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	tasks   []func() // The tasks waiting for a worker.
+	stopped bool     // Whether the workers exit once the queue is empty.
+}
+
+var pool atomic.Pointer[workerPool]
+
+// SetWorkerPoolSize makes Async run providers on a pool of n goroutines instead of
+// a new goroutine per call, queueing them while all workers are busy.
+// A size of zero, the default, restores one goroutine per call.
+// Futures already queued still run on the previous pool.
+// A provider that awaits another Future created with Async holds its worker while waiting,
+// so with a pool smaller than the depth of such nesting the providers deadlock.
+func SetWorkerPoolSize(n int) {
+	var p *workerPool
+	if n > 0 {
+		p = newWorkerPool(n)
+	}
+
+	if old := pool.Swap(p); old != nil {
+		old.stop()
+	}
+}
+
+func newWorkerPool(n int) *workerPool {
+	p := &workerPool{}
+	p.cond = sync.NewCond(&p.mu)
+	for range n {
+		go p.work()
+	}
+	return p
+}
+
+func (p *workerPool) submit(task func()) {
+	p.mu.Lock()
+	p.tasks = append(p.tasks, task)
+	p.mu.Unlock()
+	p.cond.Signal()
+}
+
+func (p *workerPool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.tasks) == 0 && !p.stopped {
+			p.cond.Wait()
+		}
+
+		if len(p.tasks) == 0 {
+			p.mu.Unlock()
+			return
+		}
+
+		task := p.tasks[0]
+		p.tasks = p.tasks[1:]
+		p.mu.Unlock()
+		task()
+	}
+}
+
+func (p *workerPool) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
@@ -0,0 +1,97 @@
+package gfuture
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetWorkerPoolSize(t *testing.T) {
+	// given
+	ctx := context.Background()
+	SetWorkerPoolSize(2)
+	defer SetWorkerPoolSize(0)
+	var running, peak atomic.Int32
+	futures := make([]Future[int], 5)
+	// when
+	for i := range futures {
+		futures[i] = Async(func() (int, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return i, nil
+		})
+	}
+	// then
+	for i, future := range futures {
+		value, err := future.Await(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if value != i {
+			t.Fatalf("Expected value %v, got %v", i, value)
+		}
+	}
+
+	if peak.Load() != 2 {
+		t.Fatalf("Expected at most 2 concurrent providers, got %v", peak.Load())
+	}
+}
+
+func TestSetWorkerPoolSizeWithCombinators(t *testing.T) {
+	// given
+	ctx := context.Background()
+	SetWorkerPoolSize(1)
+	defer SetWorkerPoolSize(0)
+	// when
+	value, err := Async(func() (int, error) {
+		return 21, nil
+	}).Transform(ctx, func(v int, err error) (int, error) {
+		return v * 2, err
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestSetWorkerPoolSizeWithBlockingHelper(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	SetWorkerPoolSize(1)
+	defer SetWorkerPoolSize(0)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	waiting := FromWaitGroup(&wg)
+	// when
+	value, err := Async(func() (int, error) {
+		wg.Done()
+		return 42, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if _, err := waiting.Await(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
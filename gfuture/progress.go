@@ -0,0 +1,34 @@
+package gfuture
+
+// ProgressFuture is a Future that also publishes progress values while it is being computed.
+type ProgressFuture[T, P any] struct {
+	Future[T]
+	progress chan P // The channel progress values are published on.
+}
+
+// AsyncProgress creates a ProgressFuture and executes the provided function asynchronously.
+// The function publishes progress values by calling report, and its result is resolved into the Future.
+// Progress values are dropped when the consumer does not keep up with them.
+func AsyncProgress[T, P any](provider func(report func(P)) (T, error)) ProgressFuture[T, P] {
+	pf := ProgressFuture[T, P]{
+		Future:   NewFuture[T](),
+		progress: make(chan P, 1),
+	}
+	go func() {
+		value, err := provider(func(p P) {
+			select {
+			case pf.progress <- p:
+			default:
+			}
+		})
+		close(pf.progress)
+		pf.Resolve(value, err)
+	}()
+	return pf
+}
+
+// Progress returns the channel progress values are published on.
+// The channel is closed when the provider returns.
+func (pf ProgressFuture[T, P]) Progress() <-chan P {
+	return pf.progress
+}
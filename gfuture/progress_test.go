@@ -0,0 +1,38 @@
+package gfuture
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAsyncProgress(t *testing.T) {
+	// given
+	ctx := context.Background()
+	ack := make(chan struct{})
+	future := AsyncProgress(func(report func(int)) (string, error) {
+		for i := 1; i <= 3; i++ {
+			report(i * 10)
+			<-ack
+		}
+		return "done", nil
+	})
+	// when
+	var progress []int
+	for p := range future.Progress() {
+		progress = append(progress, p)
+		ack <- struct{}{}
+	}
+	value, err := future.Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != "done" {
+		t.Fatalf("Expected value done, got %v", value)
+	}
+
+	if len(progress) != 3 || progress[0] != 10 || progress[2] != 30 {
+		t.Fatalf("Expected progress [10 20 30], got %v", progress)
+	}
+}
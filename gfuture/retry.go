@@ -0,0 +1,204 @@
+package gfuture
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Repeatable is an asynchronous operation that can be awaited with retries,
+// obtaining a fresh Future from its factory on each new attempt.
+type Repeatable[T any] struct {
+	first    Future[T]        // The Future used by the first attempt.
+	factory  func() Future[T] // Creates the Future of every later attempt.
+	attempts int              // The maximum number of attempts.
+	backoff  time.Duration    // The delay before each new attempt, doubled every time.
+	jitter   time.Duration    // The upper bound of the random delay added to each backoff.
+}
+
+// Repeatable returns a Repeatable whose first attempt awaits the Future and whose later
+// attempts await a new Future obtained from factory.
+// By default it makes a single attempt with no backoff.
+func (f Future[T]) Repeatable(factory func() Future[T]) Repeatable[T] {
+	return Repeatable[T]{first: f, factory: factory, attempts: 1}
+}
+
+// WithAttempts returns a copy of the Repeatable that makes at most n attempts.
+func (r Repeatable[T]) WithAttempts(n int) Repeatable[T] {
+	r.attempts = max(n, 1)
+	return r
+}
+
+// WithBackoff returns a copy of the Repeatable that waits d before the second attempt,
+// doubling the delay before every later one.
+func (r Repeatable[T]) WithBackoff(d time.Duration) Repeatable[T] {
+	r.backoff = d
+	return r
+}
+
+// WithJitter returns a copy of the Repeatable that adds a random delay of up to d
+// to every backoff, so that concurrent retries do not happen all at once.
+func (r Repeatable[T]) WithJitter(d time.Duration) Repeatable[T] {
+	r.jitter = d
+	return r
+}
+
+// Await runs the attempts until one succeeds and returns its value, or returns the
+// error of the last attempt.
+// If the context is done, it stops retrying and returns the cancellation error.
+func (r Repeatable[T]) Await(ctx context.Context) (T, error) {
+	value, err := r.first.Await(ctx)
+	delay := r.backoff
+	for attempt := 1; attempt < r.attempts && err != nil; attempt++ {
+		if err := sleep(ctx, delay+r.randomJitter()); err != nil {
+			var zero T
+			return zero, err
+		}
+		delay *= 2
+		value, err = r.factory().Await(ctx)
+	}
+	return value, err
+}
+
+func (r Repeatable[T]) randomJitter() time.Duration {
+	if r.jitter <= 0 {
+		return 0
+	}
+	return rand.N(r.jitter)
+}
+
+// AwaitWithJitter waits for the Future to resolve and, while it fails, retries up to attempts
+// times in total with futures obtained from factory.
+// The delay before each retry starts at base, doubles every time and gets a random fraction
+// of base added to it.
+func (f Future[T]) AwaitWithJitter(ctx context.Context, factory func() Future[T], attempts int, base time.Duration) (T, error) {
+	return f.Repeatable(factory).WithAttempts(attempts).WithBackoff(base).WithJitter(base).Await(ctx)
+}
+
+// RetryEscalating creates a Future that executes the provider asynchronously once per timeout,
+// each attempt with its own context bounded by the next timeout, until one succeeds.
+// It resolves with the first success or the last error, and with no timeouts the provider
+// is called once with ctx as is.
+func RetryEscalating[T any](ctx context.Context, timeouts []time.Duration, provider func(context.Context) (T, error)) Future[T] {
+	return spawn(func() (T, error) {
+		if len(timeouts) == 0 {
+			return provider(ctx)
+		}
+
+		var value T
+		var err error
+		for _, timeout := range timeouts {
+			value, err = func() (T, error) {
+				ctx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+				return provider(ctx)
+			}()
+			if err == nil {
+				return value, nil
+			}
+
+			if ctx.Err() != nil {
+				var zero T
+				return zero, cancelled(ctx)
+			}
+		}
+		return value, err
+	})
+}
+
+// Hedge creates a Future that executes the provider asynchronously and, if it has not returned
+// within delay, starts a second call. It resolves with the result of whichever call returns first,
+// and the context given to the calls is cancelled so the other one can stop.
+// It is meant for idempotent providers, since both calls may run to completion.
+func Hedge[T any](ctx context.Context, delay time.Duration, provider func(context.Context) (T, error)) Future[T] {
+	return spawn(func() (T, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		results := make(chan Result[T], 2)
+		call := func() {
+			go func() {
+				value, err := provider(ctx)
+				results <- Result[T]{Value: value, Err: err}
+			}()
+		}
+
+		call()
+		timer := currentClock().NewTimer(delay)
+		defer timer.Stop()
+		for {
+			select {
+			case result := <-results:
+				return result.Value, result.Err
+			case <-timer.C():
+				call()
+			case <-ctx.Done():
+				var zero T
+				return zero, cancelled(ctx)
+			}
+		}
+	})
+}
+
+// sleep pauses for d, returning the cancellation error if the context is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := currentClock().NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return cancelled(ctx)
+	}
+}
+
+// Breaker is a circuit breaker shared by the futures created through it.
+// It opens after a number of consecutive failures and, while open, refuses to run providers.
+type Breaker struct {
+	mu          sync.Mutex
+	threshold   int           // The consecutive failures that open the circuit.
+	cooldown    time.Duration // How long the circuit stays open before a new attempt is allowed.
+	failures    int           // The current number of consecutive failures.
+	lastFailure time.Time     // When the last failure happened.
+}
+
+// NewBreaker creates a Breaker that opens after threshold consecutive failures
+// and lets a new attempt through once cooldown has elapsed.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: max(threshold, 1), cooldown: cooldown}
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures < b.threshold || since(b.lastFailure) >= b.cooldown
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.failures++
+		b.lastFailure = currentClock().Now()
+	} else {
+		b.failures = 0
+	}
+}
+
+// BreakerAsync creates a Future that executes the provider asynchronously through the Breaker.
+// While the Breaker is open it resolves right away with ErrCircuitOpen, without calling the provider.
+// Successful calls reset the failure count of the Breaker, and failed calls increment it.
+func BreakerAsync[T any](b *Breaker, provider func() (T, error)) Future[T] {
+	if !b.allow() {
+		f := make(Future[T], 1)
+		f <- payload[T]{err: ErrCircuitOpen}
+		close(f)
+		return f
+	}
+
+	return Async(func() (T, error) {
+		value, err := provider()
+		b.record(err)
+		return value, err
+	})
+}
@@ -0,0 +1,226 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRepeatable(t *testing.T) {
+	// given
+	ctx := context.Background()
+	attempts := 0
+	factory := func() Future[int] {
+		return Async(func() (int, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, errors.New("test error")
+			}
+			return 42, nil
+		})
+	}
+	// when
+	value, err := factory().Repeatable(factory).WithAttempts(3).WithBackoff(10 * time.Millisecond).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %v", attempts)
+	}
+}
+
+func TestRepeatableWithExhaustedAttempts(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	attempts := 0
+	factory := func() Future[int] {
+		return Async(func() (int, error) {
+			attempts++
+			return 0, expectedErr
+		})
+	}
+	// when
+	_, err := factory().Repeatable(factory).WithAttempts(2).Await(ctx)
+	// then
+	if err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("Expected 2 attempts, got %v", attempts)
+	}
+}
+
+func TestAwaitWithJitter(t *testing.T) {
+	// given
+	ctx := context.Background()
+	attempts := 0
+	factory := func() Future[int] {
+		return Async(func() (int, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, errors.New("test error")
+			}
+			return 42, nil
+		})
+	}
+	// when
+	value, err := factory().AwaitWithJitter(ctx, factory, 3, 10*time.Millisecond)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %v", attempts)
+	}
+}
+
+func TestRetryEscalating(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var deadlines []time.Duration
+	provider := func(ctx context.Context) (int, error) {
+		deadline, _ := ctx.Deadline()
+		deadlines = append(deadlines, time.Until(deadline).Round(10*time.Millisecond))
+		select {
+		case <-time.After(30 * time.Millisecond):
+			return 42, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	timeouts := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, time.Second}
+	// when
+	value, err := RetryEscalating(ctx, timeouts, provider).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if len(deadlines) != 3 || deadlines[2] != time.Second {
+		t.Fatalf("Expected 3 attempts with escalating timeouts, got %v", deadlines)
+	}
+}
+
+func TestRetryEscalatingWithExhaustedTimeouts(t *testing.T) {
+	// given
+	ctx := context.Background()
+	provider := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	timeouts := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+	// when
+	_, err := RetryEscalating(ctx, timeouts, provider).Await(ctx)
+	// then
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestHedge(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var calls atomic.Int32
+	loserCancelled := make(chan struct{})
+	provider := func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			close(loserCancelled)
+			return 0, ctx.Err()
+		}
+		return 42, nil
+	}
+	// when
+	value, err := Hedge(ctx, 10*time.Millisecond, provider).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	select {
+	case <-loserCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the slow call to be cancelled")
+	}
+}
+
+func TestHedgeWithFastCall(t *testing.T) {
+	// given
+	ctx := context.Background()
+	var calls atomic.Int32
+	provider := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		return 42, nil
+	}
+	// when
+	value, err := Hedge(ctx, time.Second, provider).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 || calls.Load() != 1 {
+		t.Fatalf("Expected value 42 from a single call, got %v from %v calls", value, calls.Load())
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	// given
+	ctx := context.Background()
+	clock := newFakeClock(t)
+	breaker := NewBreaker(2, time.Minute)
+	calls := 0
+	failing := func() (int, error) {
+		calls++
+		return 0, errors.New("test error")
+	}
+	succeeding := func() (int, error) {
+		calls++
+		return 42, nil
+	}
+	// when
+	BreakerAsync(breaker, failing).Await(ctx)
+	BreakerAsync(breaker, failing).Await(ctx)
+	_, openErr := BreakerAsync(breaker, succeeding).Await(ctx)
+	clock.Advance(time.Minute)
+	value, err := BreakerAsync(breaker, succeeding).Await(ctx)
+	// then
+	if openErr != ErrCircuitOpen {
+		t.Fatalf("Expected error %v, got %v", ErrCircuitOpen, openErr)
+	}
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+
+	if calls != 3 {
+		t.Fatalf("Expected 3 calls, got %v", calls)
+	}
+}
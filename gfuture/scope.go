@@ -0,0 +1,91 @@
+package gfuture
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Scope ties the lifetime of a group of futures to a context.
+// Cancelling the scope, or its parent context, resolves every pending Future
+// created in the scope with the cancellation error.
+type Scope struct {
+	ctx    context.Context    // The context shared by the futures of the scope.
+	cancel context.CancelFunc // Cancels the context of the scope.
+	wg     sync.WaitGroup     // Tracks the providers still running in the scope.
+}
+
+// NewScope creates a new Scope derived from the given context.
+func NewScope(ctx context.Context) *Scope {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scope{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context of the Scope, which is done once the Scope is cancelled.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Cancel cancels the Scope and every Future created in it.
+func (s *Scope) Cancel() {
+	s.cancel()
+}
+
+// Wait blocks until the providers of every Future created in the Scope have returned.
+func (s *Scope) Wait() {
+	s.wg.Wait()
+}
+
+// ScopeAsync creates a Future in the Scope and executes the provider asynchronously
+// with the context of the Scope.
+// If the Scope is cancelled before the provider returns, the Future resolves with the cancellation error.
+func ScopeAsync[T any](s *Scope, provider func(context.Context) (T, error)) Future[T] {
+	s.wg.Add(1)
+	done := make(chan payload[T], 1)
+	go func() {
+		defer s.wg.Done()
+		value, err := provider(s.ctx)
+		done <- payload[T]{val: value, err: err}
+	}()
+
+	f := NewFuture[T]()
+	go func() {
+		select {
+		case p := <-done:
+			f.sendAndClose(p)
+		case <-s.ctx.Done():
+			f.Error(cancelled(s.ctx))
+		}
+	}()
+	return f
+}
+
+// Budget is an end-to-end time budget shared by every step of a chain of futures.
+// Each step awaited with the context of the Budget only gets the time left by the previous steps.
+type Budget struct {
+	ctx    context.Context    // The context whose deadline is the end of the budget.
+	cancel context.CancelFunc // Releases the resources of the context.
+}
+
+// NewBudget creates a Budget of the given total duration, derived from the given context.
+func NewBudget(ctx context.Context, total time.Duration) *Budget {
+	ctx, cancel := context.WithTimeout(ctx, total)
+	return &Budget{ctx: ctx, cancel: cancel}
+}
+
+// Context returns a context that is done once the Budget runs out.
+func (b *Budget) Context() context.Context {
+	return b.ctx
+}
+
+// Remaining returns the time left in the Budget, which is never more than the time left
+// before the deadline of the parent context.
+func (b *Budget) Remaining() time.Duration {
+	deadline, _ := b.ctx.Deadline()
+	return max(time.Until(deadline), 0)
+}
+
+// Cancel ends the Budget early, releasing the resources of its context.
+func (b *Budget) Cancel() {
+	b.cancel()
+}
@@ -0,0 +1,107 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScopeAsync(t *testing.T) {
+	// given
+	ctx := context.Background()
+	scope := NewScope(ctx)
+	defer scope.Cancel()
+	// when
+	value, err := ScopeAsync(scope, func(ctx context.Context) (int, error) {
+		return 42, nil
+	}).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestScopeCancel(t *testing.T) {
+	// given
+	ctx := context.Background()
+	scope := NewScope(ctx)
+	futures := []Future[int]{}
+	for i := 0; i < 3; i++ {
+		futures = append(futures, ScopeAsync(scope, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}))
+	}
+	// when
+	scope.Cancel()
+	scope.Wait()
+	// then
+	for _, future := range futures {
+		if _, err := future.Await(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context canceled error, got %v", err)
+		}
+	}
+}
+
+func TestScopeWithCancelledParent(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	scope := NewScope(ctx)
+	// when
+	_, err := ScopeAsync(scope, func(ctx context.Context) (int, error) {
+		time.Sleep(time.Second)
+		return 42, nil
+	}).Await(context.Background())
+	// then
+	if !errors.Is(err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, err)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	// given
+	budget := NewBudget(context.Background(), 150*time.Millisecond)
+	defer budget.Cancel()
+	step := func() Future[int] {
+		return Async(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 42, nil
+		})
+	}
+	// when
+	_, firstErr := step().Await(budget.Context())
+	remaining := budget.Remaining()
+	_, secondErr := step().Await(budget.Context())
+	// then
+	if firstErr != nil {
+		t.Fatalf("Unexpected error: %v", firstErr)
+	}
+
+	if remaining > 50*time.Millisecond {
+		t.Fatalf("Expected at most 50ms remaining, got %v", remaining)
+	}
+
+	if !errors.Is(secondErr, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline exceeded error, got %v", secondErr)
+	}
+}
+
+func TestBudgetWithEarlierParentDeadline(t *testing.T) {
+	// given
+	parent, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	budget := NewBudget(parent, time.Hour)
+	defer budget.Cancel()
+	// when
+	remaining := budget.Remaining()
+	// then
+	if remaining > 50*time.Millisecond {
+		t.Fatalf("Expected at most 50ms remaining, got %v", remaining)
+	}
+}
@@ -0,0 +1,294 @@
+package gfuture
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// StreamResults runs all providers concurrently and emits each result on the returned
+// channel as it completes, in completion order.
+// The channel is closed once every provider has completed or the context is done.
+func StreamResults[T any](ctx context.Context, providers []func() (T, error)) <-chan Result[T] {
+	out := make(chan Result[T], len(providers))
+	go func() {
+		defer close(out)
+		for result := range settle(ctx, Spread(providers, Async)) {
+			out <- result.Result
+		}
+	}()
+	return out
+}
+
+// Interleave awaits the futures received from both channels and emits their results on the
+// returned channel as they resolve, alternating between the channels when both have a result ready.
+// The channel is closed once both input channels are closed or the context is done.
+func Interleave[T any](ctx context.Context, a, b <-chan Future[T]) <-chan Result[T] {
+	out := make(chan Result[T])
+	go func() {
+		defer close(out)
+		var headA, headB Future[T]
+		defer func() {
+			for _, head := range []Future[T]{headA, headB} {
+				if head != nil {
+					head.drain()
+				}
+			}
+		}()
+		fill := func(in *<-chan Future[T], head *Future[T]) {
+			if *head != nil || *in == nil {
+				return
+			}
+			select {
+			case f, ok := <-*in:
+				if !ok {
+					*in = nil
+					return
+				}
+				*head = f
+			default:
+			}
+		}
+		preferred, other := &headA, &headB
+		for {
+			fill(&a, &headA)
+			fill(&b, &headB)
+			if a == nil && b == nil && headA == nil && headB == nil {
+				return
+			}
+
+			var p payload[T]
+			var side *Future[T]
+			select {
+			case p = <-*preferred:
+				side = preferred
+			default:
+				inA, inB := a, b
+				if headA != nil {
+					inA = nil
+				}
+				if headB != nil {
+					inB = nil
+				}
+				select {
+				case p = <-headA:
+					side = &headA
+				case p = <-headB:
+					side = &headB
+				case f, ok := <-inA:
+					if ok {
+						headA = f
+					} else {
+						a = nil
+					}
+					continue
+				case f, ok := <-inB:
+					if ok {
+						headB = f
+					} else {
+						b = nil
+					}
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			*side = nil
+			if side == preferred {
+				preferred, other = other, preferred
+			}
+			select {
+			case out <- Result[T]{Value: p.val, Err: p.err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// CollectLatest returns a Future resolved with the result of the last Future received
+// from the channel once no new Future has arrived for the quiet duration, or the channel is closed.
+// Earlier futures are drained and their results discarded.
+// If the channel is closed before any Future is received, it resolves with ErrNoFuture.
+func CollectLatest[T any](ctx context.Context, in <-chan Future[T], quiet time.Duration) Future[T] {
+	return spawn(func() (T, error) {
+		var latest Future[T]
+		var timeout <-chan time.Time
+		timer := currentClock().NewTimer(quiet)
+		defer timer.Stop()
+		for {
+			select {
+			case f, ok := <-in:
+				if !ok {
+					if latest == nil {
+						var zero T
+						return zero, ErrNoFuture
+					}
+					return latest.Await(ctx)
+				}
+
+				if latest != nil {
+					latest.drain()
+				}
+				latest = f
+				timer.Reset(quiet)
+				timeout = timer.C()
+			case <-timeout:
+				return latest.Await(ctx)
+			case <-ctx.Done():
+				if latest != nil {
+					latest.drain()
+				}
+				var zero T
+				return zero, cancelled(ctx)
+			}
+		}
+	})
+}
+
+// Seq returns a sequence that awaits the Future and yields its value and error exactly once.
+func (f Future[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		yield(f.Await(ctx))
+	}
+}
+
+// Stream returns a sequence that yields the value and error of each Future as it resolves,
+// in completion order.
+// Breaking out of the loop abandons the remaining futures.
+func Stream[T any](ctx context.Context, futures []Future[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for result := range settle(ctx, futures) {
+			if !yield(result.Value, result.Err) {
+				return
+			}
+		}
+	}
+}
+
+// AwaitInto waits for the Future to resolve and sends the value to valCh on success,
+// or the error to errCh on failure.
+// Exactly one send happens.
+func (f Future[T]) AwaitInto(ctx context.Context, valCh chan<- T, errCh chan<- error) {
+	value, err := f.Await(ctx)
+	if err != nil {
+		errCh <- err
+		return
+	}
+	valCh <- value
+}
+
+// Repeat returns a channel that receives a new Future every interval, each resolved
+// with a fresh call to the provider.
+// The channel is closed once the context is done.
+func Repeat[T any](ctx context.Context, interval time.Duration, provider func() (T, error)) <-chan Future[T] {
+	out := make(chan Future[T])
+	go func() {
+		defer close(out)
+		timer := currentClock().NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C():
+				timer.Reset(interval)
+				f := Async(provider)
+				select {
+				case out <- f:
+				case <-ctx.Done():
+					f.drain()
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Process reads items from the input channel, applies fn to them using a pool of workers,
+// and emits a Future with the result of each item on the returned channel.
+// Futures are emitted as items complete, so the input order is not preserved.
+// The channel is closed once the input channel is drained or the context is done.
+func Process[T, U any](ctx context.Context, in <-chan T, workers int, fn func(T) (U, error)) <-chan Future[U] {
+	out := make(chan Future[U])
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+
+					f := NewFuture[U]()
+					value, err := fn(item)
+					go f.Resolve(value, err)
+					select {
+					case out <- f:
+					case <-ctx.Done():
+						f.drain()
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// EMA awaits each Future received from the input channel and emits the exponential moving
+// average of their values, smoothed by alpha, on the returned channel.
+// The first value initializes the average, and futures that fail are skipped.
+// The channel is closed once the input channel is closed or the context is done.
+func EMA(ctx context.Context, alpha float64, in <-chan Future[float64]) <-chan float64 {
+	out := make(chan float64)
+	go func() {
+		defer close(out)
+		var average float64
+		started := false
+		for {
+			select {
+			case f, ok := <-in:
+				if !ok {
+					return
+				}
+
+				value, err := f.Await(ctx)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					continue
+				}
+
+				if started {
+					average = alpha*value + (1-alpha)*average
+				} else {
+					average, started = value, true
+				}
+
+				select {
+				case out <- average:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,316 @@
+package gfuture
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStreamResults(t *testing.T) {
+	// given
+	ctx := context.Background()
+	providers := []func() (int, error){
+		func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		},
+		func() (int, error) {
+			return 2, nil
+		},
+	}
+	// when
+	var values []int
+	for result := range StreamResults(ctx, providers) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		values = append(values, result.Value)
+	}
+	// then
+	if len(values) != 2 || values[0] != 2 || values[1] != 1 {
+		t.Fatalf("Expected values [2 1], got %v", values)
+	}
+}
+
+func TestStreamResultsWithCancelledContext(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	providers := []func() (int, error){
+		func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		},
+	}
+	// when
+	var results []Result[int]
+	for result := range StreamResults(ctx, providers) {
+		results = append(results, result)
+	}
+	// then
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %v", len(results))
+	}
+
+	if !errors.Is(results[0].Err, ErrAwaitCancelled) {
+		t.Fatalf("Expected error %v, got %v", ErrAwaitCancelled, results[0].Err)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	// given
+	ctx := context.Background()
+	feed := func(values ...int) <-chan Future[int] {
+		in := make(chan Future[int], len(values))
+		for _, value := range values {
+			in <- Async(func() (int, error) {
+				return value, nil
+			})
+		}
+		close(in)
+		return in
+	}
+	a, b := feed(1, 3, 5), feed(2, 4)
+	time.Sleep(50 * time.Millisecond)
+	// when
+	var values []int
+	for result := range Interleave(ctx, a, b) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		values = append(values, result.Value)
+	}
+	// then
+	if len(values) != 5 {
+		t.Fatalf("Expected 5 values, got %v", values)
+	}
+
+	for i, value := range values {
+		if value != i+1 {
+			t.Fatalf("Expected values [1 2 3 4 5], got %v", values)
+		}
+	}
+}
+
+func TestCollectLatest(t *testing.T) {
+	// given
+	ctx := context.Background()
+	in := make(chan Future[int])
+	go func() {
+		for i := 1; i <= 3; i++ {
+			in <- Async(func() (int, error) {
+				return i, nil
+			})
+		}
+	}()
+	// when
+	value, err := CollectLatest(ctx, in, 100*time.Millisecond).Await(ctx)
+	// then
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value != 3 {
+		t.Fatalf("Expected value 3, got %v", value)
+	}
+}
+
+func TestCollectLatestWithClosedChannel(t *testing.T) {
+	// given
+	ctx := context.Background()
+	in := make(chan Future[int])
+	close(in)
+	// when
+	_, err := CollectLatest(ctx, in, 100*time.Millisecond).Await(ctx)
+	// then
+	if err != ErrNoFuture {
+		t.Fatalf("Expected error %v, got %v", ErrNoFuture, err)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	// given
+	ctx := context.Background()
+	future := Async(func() (int, error) {
+		return 42, nil
+	})
+	// when
+	count := 0
+	for value, err := range future.Seq(ctx) {
+		count++
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if value != 42 {
+			t.Fatalf("Expected value 42, got %v", value)
+		}
+	}
+	// then
+	if count != 1 {
+		t.Fatalf("Expected 1 iteration, got %v", count)
+	}
+}
+
+func TestStream(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		}),
+		Async(func() (int, error) {
+			return 2, nil
+		}),
+	}
+	// when
+	var values []int
+	for value, err := range Stream(ctx, futures) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		values = append(values, value)
+	}
+	// then
+	if len(values) != 2 || values[0] != 2 || values[1] != 1 {
+		t.Fatalf("Expected values [2 1], got %v", values)
+	}
+}
+
+func TestStreamWithBreak(t *testing.T) {
+	// given
+	ctx := context.Background()
+	futures := []Future[int]{
+		Async(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		}),
+		Async(func() (int, error) {
+			return 2, nil
+		}),
+	}
+	// when
+	var values []int
+	for value := range Stream(ctx, futures) {
+		values = append(values, value)
+		break
+	}
+	// then
+	if len(values) != 1 || values[0] != 2 {
+		t.Fatalf("Expected values [2], got %v", values)
+	}
+}
+
+func TestAwaitInto(t *testing.T) {
+	// given
+	ctx := context.Background()
+	valCh := make(chan int, 1)
+	errCh := make(chan error, 1)
+	// when
+	Async(func() (int, error) {
+		return 42, nil
+	}).AwaitInto(ctx, valCh, errCh)
+	// then
+	if len(errCh) != 0 {
+		t.Fatalf("Unexpected error: %v", <-errCh)
+	}
+
+	if value := <-valCh; value != 42 {
+		t.Fatalf("Expected value 42, got %v", value)
+	}
+}
+
+func TestAwaitIntoWithError(t *testing.T) {
+	// given
+	ctx := context.Background()
+	expectedErr := errors.New("test error")
+	valCh := make(chan int, 1)
+	errCh := make(chan error, 1)
+	// when
+	Async(func() (int, error) {
+		return 0, expectedErr
+	}).AwaitInto(ctx, valCh, errCh)
+	// then
+	if len(valCh) != 0 {
+		t.Fatalf("Unexpected value: %v", <-valCh)
+	}
+
+	if err := <-errCh; err != expectedErr {
+		t.Fatalf("Expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	var calls atomic.Int32
+	// when
+	var values []int32
+	for future := range Repeat(ctx, 100*time.Millisecond, func() (int32, error) {
+		return calls.Add(1), nil
+	}) {
+		value, err := future.Await(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		values = append(values, value)
+	}
+	// then
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("Expected values [1 2], got %v", values)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	// given
+	ctx := context.Background()
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 5; i++ {
+			in <- i
+		}
+	}()
+	// when
+	sum := 0
+	for future := range Process(ctx, in, 2, func(item int) (int, error) {
+		return item * 2, nil
+	}) {
+		value, err := future.Await(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sum += value
+	}
+	// then
+	if sum != 30 {
+		t.Fatalf("Expected sum 30, got %v", sum)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	// given
+	ctx := context.Background()
+	in := make(chan Future[float64])
+	go func() {
+		defer close(in)
+		for _, value := range []float64{10, 20} {
+			in <- Async(func() (float64, error) { return value, nil })
+		}
+		in <- Async(func() (float64, error) { return 0, errors.New("test error") })
+		in <- Async(func() (float64, error) { return 30, nil })
+	}()
+	// when
+	var averages []float64
+	for average := range EMA(ctx, 0.5, in) {
+		averages = append(averages, average)
+	}
+	// then
+	if len(averages) != 3 || averages[0] != 10 || averages[1] != 15 || averages[2] != 22.5 {
+		t.Fatalf("Expected averages [10 15 22.5], got %v", averages)
+	}
+}